@@ -7,12 +7,18 @@ import (
 	"github.com/swaggo/http-swagger"
 	_ "github.com/yourgfslove/GodFoodApi/docs"
 	"github.com/yourgfslove/GodFoodApi/internal/config"
-	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/courierHub"
+	"github.com/yourgfslove/GodFoodApi/internal/dispatch"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"github.com/yourgfslove/GodFoodApi/internal/events"
+	"github.com/yourgfslove/GodFoodApi/internal/geocode"
 	myrouter "github.com/yourgfslove/GodFoodApi/internal/http-server/router"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"github.com/yourgfslove/GodFoodApi/internal/storage"
 	"log/slog"
 	"net/http"
 	"os"
+	_ "time/tzdata" // restaurant time zones must resolve even without system tzdata
 )
 
 // @title GodFood API
@@ -29,19 +35,43 @@ func main() {
 
 	log.Info("starting server")
 	log.Debug("Debug logging enabled")
-	storage, err := sql.Open("postgres", cfg.StorageURL)
+	db, err := sql.Open("postgres", cfg.StorageURL)
 	if err != nil {
-		log.Error("failed init storage", err)
+		log.Error("failed init storage", sl.Err(err))
 		os.Exit(1)
 	}
-	DBQueries := database.New(storage)
+	DBStorage := storage.New(db)
+
+	geocoder, err := geocode.New(
+		cfg.Geocoder.Provider,
+		geo.Point{Lat: cfg.Geocoder.StubLat, Lng: cfg.Geocoder.StubLng},
+		cfg.Geocoder.StubRadiusKm)
+	if err != nil {
+		log.Error("failed init geocoder", sl.Err(err))
+		os.Exit(1)
+	}
+
+	strategy, err := dispatch.NewStrategy(cfg.Dispatch.Strategy, dispatch.NewLocator(DBStorage, geocoder))
+	if err != nil {
+		log.Error("failed init dispatch strategy", sl.Err(err))
+		os.Exit(1)
+	}
+
+	hub := courierHub.New(log, DBStorage)
+	dispatcher := dispatch.New(log, DBStorage, strategy, hub, cfg.Dispatch.OfferTimeout)
+	defer dispatcher.Stop()
+
 	router := myrouter.New(log)
 	deps := &myrouter.Deps{
-		Storage: DBQueries,
-		Logger:  log,
+		Storage:    DBStorage,
+		Logger:     log,
+		Dispatcher: dispatcher,
+		Events:     events.New(log, DBStorage),
+		CourierHub: hub,
+		Geocoder:   geocoder,
 		Cfg: struct {
 			SecretJWT string
-		}{},
+		}{SecretJWT: cfg.SecretJWT},
 	}
 	router.Get("/docs/*", httpSwagger.WrapHandler)
 	myrouter.SetupRoutes(router, deps)
@@ -12,6 +12,8 @@ type Config struct {
 	StorageURL string `yaml:"storage_url" env:"STORAGE_URL" env-required:"true"`
 	SecretJWT  string `yaml:"secret_jwt" env:"SECRET_JWT"`
 	HTTPServer `yaml:"http_server" env:"HTTP_SERVER" env-required:"true"`
+	Dispatch   `yaml:"dispatch"`
+	Geocoder   `yaml:"geocoder"`
 }
 type HTTPServer struct {
 	Address     string        `yaml:"address" env-default:"localhost:8081"`
@@ -19,6 +21,21 @@ type HTTPServer struct {
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
 }
 
+// Dispatch configures automatic courier dispatch, strategy is round_robin, least_loaded or nearest.
+type Dispatch struct {
+	Strategy     string        `yaml:"strategy" env:"DISPATCH_STRATEGY" env-default:"least_loaded"`
+	OfferTimeout time.Duration `yaml:"offer_timeout" env:"DISPATCH_OFFER_TIMEOUT" env-default:"30s"`
+}
+
+// Geocoder configures address geocoding. The stub provider works offline and places addresses
+// within StubRadiusKm of the stub center.
+type Geocoder struct {
+	Provider     string  `yaml:"provider" env:"GEOCODER_PROVIDER" env-default:"stub"`
+	StubLat      float64 `yaml:"stub_lat" env:"GEOCODER_STUB_LAT" env-default:"55.7558"`
+	StubLng      float64 `yaml:"stub_lng" env:"GEOCODER_STUB_LNG" env-default:"37.6173"`
+	StubRadiusKm float64 `yaml:"stub_radius_km" env:"GEOCODER_STUB_RADIUS_KM" env-default:"10"`
+}
+
 func MustLoadConfig() *Config {
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
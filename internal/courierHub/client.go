@@ -0,0 +1,121 @@
+package courierHub
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"time"
+)
+
+const (
+	// writeWait is how long a single write may take.
+	writeWait = 10 * time.Second
+	// pongWait is how long the connection may stay silent before it is considered dead.
+	pongWait = 60 * time.Second
+	// pingPeriod must be shorter than pongWait so a healthy client always answers in time.
+	pingPeriod = pongWait * 9 / 10
+	// maxMessageSize limits client messages, they are only read to notice pongs and close frames.
+	maxMessageSize = 512
+	// sendBuffer is how many messages a client may lag behind before it is disconnected.
+	sendBuffer = 16
+)
+
+type client struct {
+	courierID int32
+	send      chan []byte
+	// done is closed when the connection stops being readable
+	done chan struct{}
+}
+
+// Serve pushes messages for the courier into conn until the connection breaks. The open offer
+// of the courier, if any, is sent first.
+func (h *Hub) Serve(ctx context.Context, conn *websocket.Conn, courierID int32) {
+	const op = "courierHub.Serve"
+	log := h.log.With(slog.String("op", op), slog.Int("courier_id", int(courierID)))
+
+	c := h.register(courierID)
+	defer h.unregister(c)
+
+	go c.read(conn, log)
+
+	// registered before the lookup, so an offer made in between is not lost, at worst it comes twice
+	msg, ok, err := h.openOffer(ctx, courierID)
+	if err != nil {
+		log.Error("failed to get open offer", sl.Err(err))
+	}
+	if ok {
+		h.sendTo(c, msg)
+	}
+
+	c.write(conn, log)
+	conn.Close()
+	<-c.done
+}
+
+func (h *Hub) sendTo(c *client, msg Message) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		h.log.Error("failed to encode message", slog.String("op", "courierHub.sendTo"), sl.Err(err))
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.clients[c.courierID][c]; ok {
+		h.deliver(c, data)
+	}
+}
+
+// read discards client messages and keeps the read deadline moving while pongs arrive.
+func (c *client) read(conn *websocket.Conn, log *slog.Logger) {
+	defer close(c.done)
+
+	conn.SetReadLimit(maxMessageSize)
+	if err := conn.SetReadDeadline(time.Now().Add(pongWait)); err != nil {
+		return
+	}
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Info("courier connection lost", sl.Err(err))
+			}
+			return
+		}
+	}
+}
+
+func (c *client) write(conn *websocket.Conn, log *slog.Logger) {
+	ping := time.NewTicker(pingPeriod)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case data, ok := <-c.send:
+			if err := conn.SetWriteDeadline(time.Now().Add(writeWait)); err != nil {
+				return
+			}
+			// the hub dropped the client for being too slow
+			if !ok {
+				_ = conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too slow, reconnect"))
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+				log.Info("failed to write to courier", sl.Err(err))
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				log.Info("failed to ping courier", sl.Err(err))
+				return
+			}
+		}
+	}
+}
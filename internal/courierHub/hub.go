@@ -0,0 +1,223 @@
+// Package courierHub pushes dispatcher offers to couriers over WebSocket.
+//
+// The server sends JSON text messages, one object per message, told apart by "type":
+//
+//	{"type":"offer","offer":{"offer_id":4,"order_id":12,"expires_at":"...","restaurant_Name":"...",
+//	  "restaurant_Address":"...","delivery_Address":"...","reward":{...}}}
+//	    a new offer, same body as GET /orders/offer. Also sent right after connecting when the
+//	    courier already has an open offer, so an offer may arrive twice, offer_id tells them apart.
+//	{"type":"offer_withdrawn","offer_id":4,"order_id":12,"reason":"taken"}
+//	    the offer is no longer open. reason is "taken" when another courier got the order,
+//	    "cancelled" when the order can not be delivered anymore and "expired" when the time ran out.
+//	{"type":"assigned","order_id":12,"status":"ready_for_pickup"}
+//	    the order is assigned to the courier, details are in GET /orders/current.
+//
+// Offers are still accepted and declined over HTTP, messages from the client are ignored.
+// The server pings every pingPeriod and closes connections that do not answer within pongWait.
+// A client that does not read fast enough to keep up with sendBuffer messages is disconnected
+// with close code 1013 (try again later), after reconnecting it gets the open offer again.
+package courierHub
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Message types.
+const (
+	TypeOffer          = "offer"
+	TypeOfferWithdrawn = "offer_withdrawn"
+	TypeAssigned       = "assigned"
+)
+
+type Offer struct {
+	OfferID           int32       `json:"offer_id" example:"4"`
+	OrderID           int32       `json:"order_id" example:"12"`
+	ExpiresAt         string      `json:"expires_at" example:"Mon, 02 Jan 2006 15:04:05 UTC"`
+	RestaurantName    string      `json:"restaurant_Name" example:"Mac"`
+	RestaurantAddress string      `json:"restaurant_Address" example:"123 address"`
+	DeliveryAddress   string      `json:"delivery_Address" example:"122 address"`
+	Reward            money.Money `json:"reward"`
+}
+
+type Message struct {
+	Type    string `json:"type" example:"offer_withdrawn"`
+	Offer   *Offer `json:"offer,omitempty"`
+	OfferID int32  `json:"offer_id,omitempty" example:"4"`
+	OrderID int32  `json:"order_id,omitempty" example:"12"`
+	Reason  string `json:"reason,omitempty" example:"taken"`
+	Status  string `json:"status,omitempty" example:"ready_for_pickup"`
+}
+
+type store interface {
+	GetOpenOfferForCourier(ctx context.Context, courierID int32) (database.CourierOffer, error)
+	GetFullOrderByID(ctx context.Context, id int32) ([]database.GetFullOrderByIDRow, error)
+}
+
+// Hub keeps the open connections of couriers, a courier may be connected from several devices.
+type Hub struct {
+	log   *slog.Logger
+	store store
+
+	mu      sync.Mutex
+	clients map[int32]map[*client]struct{}
+}
+
+func New(log *slog.Logger, store store) *Hub {
+	return &Hub{
+		log:     log,
+		store:   store,
+		clients: make(map[int32]map[*client]struct{}),
+	}
+}
+
+// OfferCreated sends a new offer to the courier it was made to.
+func (h *Hub) OfferCreated(ctx context.Context, offer database.CourierOffer) {
+	const op = "courierHub.OfferCreated"
+
+	if !h.connected(offer.CourierID) {
+		return
+	}
+	msg, err := h.offerMessage(ctx, offer)
+	if err != nil {
+		h.log.Error("failed to build offer message", slog.String("op", op),
+			slog.Int("offer_id", int(offer.ID)), sl.Err(err))
+		return
+	}
+	h.send(offer.CourierID, msg)
+}
+
+// OfferWithdrawn tells the courier that the offer can not be accepted anymore.
+func (h *Hub) OfferWithdrawn(offer database.CourierOffer, reason string) {
+	h.send(offer.CourierID, Message{
+		Type:    TypeOfferWithdrawn,
+		OfferID: offer.ID,
+		OrderID: offer.OrderID,
+		Reason:  reason,
+	})
+}
+
+// CourierAssigned confirms that the order is now assigned to the courier.
+func (h *Hub) CourierAssigned(courierID, orderID int32, status string) {
+	h.send(courierID, Message{
+		Type:    TypeAssigned,
+		OrderID: orderID,
+		Status:  status,
+	})
+}
+
+func (h *Hub) offerMessage(ctx context.Context, offer database.CourierOffer) (Message, error) {
+	order, err := h.store.GetFullOrderByID(ctx, offer.OrderID)
+	if err != nil {
+		return Message{}, err
+	}
+	if len(order) == 0 {
+		return Message{}, sql.ErrNoRows
+	}
+	return Message{
+		Type: TypeOffer,
+		Offer: &Offer{
+			OfferID:           offer.ID,
+			OrderID:           offer.OrderID,
+			ExpiresAt:         offer.ExpiresAt.Format(time.RFC1123),
+			RestaurantName:    order[0].RestaurantName,
+			RestaurantAddress: order[0].RestaurantAddress,
+			DeliveryAddress:   order[0].DeliveryAddress,
+			Reward:            money.New(order[0].Total, order[0].Currency).Percent(5),
+		},
+	}, nil
+}
+
+// openOffer returns the message with the courier's open offer, ok is false when there is none.
+func (h *Hub) openOffer(ctx context.Context, courierID int32) (msg Message, ok bool, err error) {
+	offer, err := h.store.GetOpenOfferForCourier(ctx, courierID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Message{}, false, nil
+	}
+	if err != nil {
+		return Message{}, false, err
+	}
+	msg, err = h.offerMessage(ctx, offer)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Message{}, false, nil
+	}
+	if err != nil {
+		return Message{}, false, err
+	}
+	return msg, true, nil
+}
+
+func (h *Hub) connected(courierID int32) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients[courierID]) > 0
+}
+
+func (h *Hub) send(courierID int32, msg Message) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		h.log.Error("failed to encode message", slog.String("op", "courierHub.send"), sl.Err(err))
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.clients[courierID] {
+		h.deliver(c, data)
+	}
+}
+
+// deliver queues data for the client without blocking, a client with a full queue is dropped.
+// h.mu must be held.
+func (h *Hub) deliver(c *client, data []byte) {
+	select {
+	case c.send <- data:
+	default:
+		h.log.Warn("dropping slow courier connection", slog.Int("courier_id", int(c.courierID)))
+		h.remove(c)
+	}
+}
+
+func (h *Hub) register(courierID int32) *client {
+	c := &client{
+		courierID: courierID,
+		send:      make(chan []byte, sendBuffer),
+		done:      make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.clients[courierID] == nil {
+		h.clients[courierID] = make(map[*client]struct{})
+	}
+	h.clients[courierID][c] = struct{}{}
+	return c
+}
+
+func (h *Hub) unregister(c *client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(c)
+}
+
+// remove closes the send queue of the client, the writer then says goodbye and closes the connection.
+// h.mu must be held.
+func (h *Hub) remove(c *client) {
+	clients := h.clients[c.courierID]
+	if _, ok := clients[c]; !ok {
+		return
+	}
+	delete(clients, c)
+	if len(clients) == 0 {
+		delete(h.clients, c.courierID)
+	}
+	close(c.send)
+}
@@ -0,0 +1,146 @@
+package courierHub
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/gorilla/websocket"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeStore struct {
+	offers map[int32]database.CourierOffer
+}
+
+func (s fakeStore) GetOpenOfferForCourier(_ context.Context, courierID int32) (database.CourierOffer, error) {
+	offer, ok := s.offers[courierID]
+	if !ok {
+		return database.CourierOffer{}, sql.ErrNoRows
+	}
+	return offer, nil
+}
+
+func (s fakeStore) GetFullOrderByID(_ context.Context, id int32) ([]database.GetFullOrderByIDRow, error) {
+	return []database.GetFullOrderByIDRow{{
+		RestaurantName:  "Mac",
+		DeliveryAddress: "122 address",
+		Total:           10000,
+		Currency:        "RUB",
+	}}, nil
+}
+
+func newHub(store fakeStore) *Hub {
+	return New(slog.New(slog.NewTextHandler(io.Discard, nil)), store)
+}
+
+// dial serves the hub for courierID on a test server and connects to it.
+func dial(t *testing.T, hub *Hub, courierID int32) *websocket.Conn {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		hub.Serve(r.Context(), conn, courierID)
+	}))
+	t.Cleanup(srv.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) Message {
+	t.Helper()
+	if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+	return msg
+}
+
+func waitConnected(t *testing.T, hub *Hub, courierID int32) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if hub.connected(courierID) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("courier %d never connected", courierID)
+}
+
+func TestServe(t *testing.T) {
+	hub := newHub(fakeStore{offers: map[int32]database.CourierOffer{
+		7: {ID: 4, OrderID: 12, CourierID: 7},
+	}})
+	conn := dial(t, hub, 7)
+	other := dial(t, hub, 8)
+	waitConnected(t, hub, 7)
+	waitConnected(t, hub, 8)
+
+	// the open offer comes first on connect
+	msg := readMessage(t, conn)
+	if msg.Type != TypeOffer || msg.Offer == nil || msg.Offer.OfferID != 4 || msg.Offer.RestaurantName != "Mac" {
+		t.Fatalf("got %+v, want open offer 4", msg)
+	}
+
+	hub.OfferWithdrawn(database.CourierOffer{ID: 4, OrderID: 12, CourierID: 7}, "taken")
+	hub.CourierAssigned(8, 12, "ready_for_pickup")
+
+	msg = readMessage(t, conn)
+	if msg.Type != TypeOfferWithdrawn || msg.OfferID != 4 || msg.Reason != "taken" {
+		t.Fatalf("got %+v, want withdrawal of offer 4", msg)
+	}
+	msg = readMessage(t, other)
+	if msg.Type != TypeAssigned || msg.OrderID != 12 || msg.Status != "ready_for_pickup" {
+		t.Fatalf("got %+v, want assignment of order 12", msg)
+	}
+}
+
+func TestSlowClientDropped(t *testing.T) {
+	hub := newHub(fakeStore{})
+	slow := hub.register(7)
+	fast := hub.register(7)
+	defer hub.unregister(fast)
+
+	for i := 0; i <= sendBuffer; i++ {
+		hub.CourierAssigned(7, int32(i), "accepted")
+		if i < sendBuffer {
+			<-fast.send
+		}
+	}
+
+	for i := 0; i < sendBuffer; i++ {
+		if _, ok := <-slow.send; !ok {
+			t.Fatalf("queue closed after %d messages, want %d buffered", i, sendBuffer)
+		}
+	}
+	if _, ok := <-slow.send; ok {
+		t.Fatal("slow client was not dropped")
+	}
+	if _, ok := <-fast.send; !ok {
+		t.Fatal("fast client was dropped")
+	}
+
+	// unregistering a dropped client must not close its queue twice
+	hub.unregister(slow)
+}
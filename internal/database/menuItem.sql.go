@@ -8,26 +8,34 @@ package database
 import (
 	"context"
 	"database/sql"
+
+	"github.com/lib/pq"
 )
 
 const createMenuItem = `-- name: CreateMenuItem :one
-INSERT INTO menuitem (restaurant_id, name, price, description, available)
+INSERT INTO menuitem (restaurant_id, name, price, description, available, currency, category_id, position)
 VALUES (
         $1,
         $2,
         $3,
         $4,
-        $5
+        $5,
+        $6,
+        $7,
+        $8
 )
-RETURNING id, restaurant_id, name, price, description, available
+RETURNING id, restaurant_id, name, price, description, available, currency, deleted_at, category_id, position
 `
 
 type CreateMenuItemParams struct {
 	RestaurantID int32
 	Name         string
-	Price        float64
+	Price        int64
 	Description  sql.NullString
 	Available    sql.NullBool
+	Currency     string
+	CategoryID   sql.NullInt32
+	Position     int32
 }
 
 func (q *Queries) CreateMenuItem(ctx context.Context, arg CreateMenuItemParams) (Menuitem, error) {
@@ -37,6 +45,9 @@ func (q *Queries) CreateMenuItem(ctx context.Context, arg CreateMenuItemParams)
 		arg.Price,
 		arg.Description,
 		arg.Available,
+		arg.Currency,
+		arg.CategoryID,
+		arg.Position,
 	)
 	var i Menuitem
 	err := row.Scan(
@@ -46,13 +57,37 @@ func (q *Queries) CreateMenuItem(ctx context.Context, arg CreateMenuItemParams)
 		&i.Price,
 		&i.Description,
 		&i.Available,
+		&i.Currency,
+		&i.DeletedAt,
+		&i.CategoryID,
+		&i.Position,
 	)
 	return i, err
 }
 
+const deleteMenuItem = `-- name: DeleteMenuItem :execrows
+UPDATE menuitem
+SET deleted_at = NOW(),
+    available = false
+WHERE id=$1 AND restaurant_id=$2 AND deleted_at IS NULL
+`
+
+type DeleteMenuItemParams struct {
+	ID           int32
+	RestaurantID int32
+}
+
+func (q *Queries) DeleteMenuItem(ctx context.Context, arg DeleteMenuItemParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteMenuItem, arg.ID, arg.RestaurantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getAvailableIDByRestaurantID = `-- name: GetAvailableIDByRestaurantID :many
 SELECT id FROM menuitem
-WHERE restaurant_id=$1 AND available=true
+WHERE restaurant_id=$1 AND available=true AND deleted_at IS NULL
 `
 
 func (q *Queries) GetAvailableIDByRestaurantID(ctx context.Context, restaurantID int32) ([]int32, error) {
@@ -79,8 +114,9 @@ func (q *Queries) GetAvailableIDByRestaurantID(ctx context.Context, restaurantID
 }
 
 const getMenu = `-- name: GetMenu :many
-SELECT id, restaurant_id, name, price, description, available FROM menuitem
-WHERE restaurant_id=$1
+SELECT id, restaurant_id, name, price, description, available, currency, deleted_at, category_id, position FROM menuitem
+WHERE restaurant_id=$1 AND deleted_at IS NULL
+ORDER BY position, id
 `
 
 func (q *Queries) GetMenu(ctx context.Context, restaurantID int32) ([]Menuitem, error) {
@@ -99,6 +135,10 @@ func (q *Queries) GetMenu(ctx context.Context, restaurantID int32) ([]Menuitem,
 			&i.Price,
 			&i.Description,
 			&i.Available,
+			&i.Currency,
+			&i.DeletedAt,
+			&i.CategoryID,
+			&i.Position,
 		); err != nil {
 			return nil, err
 		}
@@ -112,3 +152,134 @@ func (q *Queries) GetMenu(ctx context.Context, restaurantID int32) ([]Menuitem,
 	}
 	return items, nil
 }
+
+const getMenuCurrency = `-- name: GetMenuCurrency :one
+SELECT currency FROM menuitem
+WHERE restaurant_id = $1 AND id <> $2 AND deleted_at IS NULL
+ORDER BY id
+LIMIT 1
+`
+
+type GetMenuCurrencyParams struct {
+	RestaurantID int32
+	ExceptID     int32
+}
+
+func (q *Queries) GetMenuCurrency(ctx context.Context, arg GetMenuCurrencyParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getMenuCurrency, arg.RestaurantID, arg.ExceptID)
+	var currency string
+	err := row.Scan(&currency)
+	return currency, err
+}
+
+const getMenuItemByID = `-- name: GetMenuItemByID :one
+SELECT id, restaurant_id, name, price, description, available, currency, deleted_at, category_id, position FROM menuitem
+WHERE id=$1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetMenuItemByID(ctx context.Context, id int32) (Menuitem, error) {
+	row := q.db.QueryRowContext(ctx, getMenuItemByID, id)
+	var i Menuitem
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.Name,
+		&i.Price,
+		&i.Description,
+		&i.Available,
+		&i.Currency,
+		&i.DeletedAt,
+		&i.CategoryID,
+		&i.Position,
+	)
+	return i, err
+}
+
+const setMenuItemsAvailability = `-- name: SetMenuItemsAvailability :many
+UPDATE menuitem
+SET available = $1
+WHERE id = ANY($2::int[]) AND restaurant_id = $3 AND deleted_at IS NULL
+RETURNING id
+`
+
+type SetMenuItemsAvailabilityParams struct {
+	Available    sql.NullBool
+	Ids          []int32
+	RestaurantID int32
+}
+
+func (q *Queries) SetMenuItemsAvailability(ctx context.Context, arg SetMenuItemsAvailabilityParams) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, setMenuItemsAvailability, arg.Available, pq.Array(arg.Ids), arg.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateMenuItem = `-- name: UpdateMenuItem :one
+UPDATE menuitem
+SET name = COALESCE($1, name),
+    price = COALESCE($2, price),
+    currency = COALESCE($3, currency),
+    description = COALESCE($4, description),
+    available = COALESCE($5, available),
+    category_id = COALESCE($6, category_id),
+    position = COALESCE($7, position)
+WHERE id = $8 AND restaurant_id = $9 AND deleted_at IS NULL
+RETURNING id, restaurant_id, name, price, description, available, currency, deleted_at, category_id, position
+`
+
+type UpdateMenuItemParams struct {
+	Name         sql.NullString
+	Price        sql.NullInt64
+	Currency     sql.NullString
+	Description  sql.NullString
+	Available    sql.NullBool
+	CategoryID   sql.NullInt32
+	Position     sql.NullInt32
+	ID           int32
+	RestaurantID int32
+}
+
+func (q *Queries) UpdateMenuItem(ctx context.Context, arg UpdateMenuItemParams) (Menuitem, error) {
+	row := q.db.QueryRowContext(ctx, updateMenuItem,
+		arg.Name,
+		arg.Price,
+		arg.Currency,
+		arg.Description,
+		arg.Available,
+		arg.CategoryID,
+		arg.Position,
+		arg.ID,
+		arg.RestaurantID,
+	)
+	var i Menuitem
+	err := row.Scan(
+		&i.ID,
+		&i.RestaurantID,
+		&i.Name,
+		&i.Price,
+		&i.Description,
+		&i.Available,
+		&i.Currency,
+		&i.DeletedAt,
+		&i.CategoryID,
+		&i.Position,
+	)
+	return i, err
+}
@@ -6,47 +6,234 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+type Address struct {
+	ID             int32
+	UserID         int32
+	Street         string
+	Building       string
+	Apartment      string
+	Entrance       string
+	Floor          string
+	Intercom       string
+	Comment        string
+	Lat            float64
+	Lng            float64
+	IsDefault      bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	LocatedExactly bool
+}
+
+type CourierLocation struct {
+	CourierID int32
+	Lat       float64
+	Lng       float64
+	Accuracy  sql.NullFloat64
+	UpdatedAt time.Time
+}
+
+type CourierOffer struct {
+	ID          int32
+	OrderID     int32
+	CourierID   int32
+	Status      string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	RespondedAt sql.NullTime
+}
+
 type Couriersstat struct {
-	ID         int32
-	Status     string
-	Ordercount sql.NullInt32
+	ID              int32
+	Status          string
+	Ordercount      int32
+	StatusChangedAt time.Time
+}
+
+type DeliveryZone struct {
+	ID           int32
+	RestaurantID int32
+	Name         string
+	Kind         string
+	CenterLat    sql.NullFloat64
+	CenterLng    sql.NullFloat64
+	RadiusM      sql.NullFloat64
+	Polygon      json.RawMessage
+	MinOrder     int64
+	DeliveryFee  int64
+	Currency     string
+	CreatedAt    time.Time
+}
+
+type IdempotencyKey struct {
+	Key          string
+	UserID       int32
+	RequestHash  string
+	StatusCode   sql.NullInt32
+	ResponseBody []byte
+	CreatedAt    time.Time
+}
+
+type MenuCategory struct {
+	ID           int32
+	RestaurantID int32
+	Name         string
+	Position     int32
+	CreatedAt    time.Time
 }
 
 type Menuitem struct {
 	ID           int32
 	RestaurantID int32
 	Name         string
-	Price        float64
+	Price        int64
 	Description  sql.NullString
 	Available    sql.NullBool
+	Currency     string
+	DeletedAt    sql.NullTime
+	CategoryID   sql.NullInt32
+	Position     int32
+}
+
+type Option struct {
+	ID         int32
+	GroupID    int32
+	Name       string
+	PriceDelta int64
+	Available  bool
+	Position   int32
+}
+
+type OptionGroup struct {
+	ID         int32
+	MenuItemID int32
+	Name       string
+	Kind       string
+	MinSelect  int32
+	MaxSelect  int32
+	Required   bool
+	Position   int32
+	CreatedAt  time.Time
 }
 
 type Order struct {
-	ID           int32
-	Customerid   int32
-	Restaurantid int32
-	Courierid    sql.NullInt32
+	ID             int32
+	Customerid     int32
+	Restaurantid   int32
+	Courierid      sql.NullInt32
+	Status         string
+	CreatedAt      sql.NullTime
+	Address        string
+	Subtotal       int64
+	Total          int64
+	Currency       string
+	AddressID      sql.NullInt32
+	DeliveryLat    sql.NullFloat64
+	DeliveryLng    sql.NullFloat64
+	DeliveryZoneID sql.NullInt32
+	DeliveryFee    int64
+}
+
+type OrderCancellation struct {
+	ID        int32
+	OrderID   int32
+	UserID    int32
+	UserRole  string
+	Reason    sql.NullString
+	CreatedAt time.Time
+}
+
+type OrderEvent struct {
+	ID           int64
+	OrderID      int32
+	RestaurantID int32
+	Type         string
 	Status       string
-	CreatedAt    sql.NullTime
-	Address      string
+	CreatedAt    time.Time
 }
 
-type Orderitem struct {
+type OrderTrackingPoint struct {
+	ID         int64
 	OrderID    int32
-	MenuItemID int32
-	Quanity    int32
+	CourierID  int32
+	Lat        float64
+	Lng        float64
+	RecordedAt time.Time
+}
+
+type Orderitem struct {
+	OrderID      int32
+	MenuItemID   int32
+	Quanity      int32
+	UnitPrice    int64
+	ItemName     string
+	Currency     string
+	ID           int32
+	OptionsPrice int64
+}
+
+type OrderitemOption struct {
+	ID          int32
+	OrderitemID int32
+	OptionID    sql.NullInt32
+	GroupName   string
+	OptionName  string
+	PriceDelta  int64
+	Currency    string
 }
 
 type Refreshtoken struct {
-	Token     string
-	CreatedAt sql.NullTime
-	UpdatedAt sql.NullTime
-	UserID    int32
-	ExpiresAt sql.NullTime
-	RevokedAt sql.NullTime
+	Token      string
+	CreatedAt  sql.NullTime
+	UpdatedAt  sql.NullTime
+	UserID     int32
+	ExpiresAt  sql.NullTime
+	RevokedAt  sql.NullTime
+	Family     string
+	ReplacedBy sql.NullString
+	UserAgent  sql.NullString
+	Ip         sql.NullString
+	ID         int64
+}
+
+type Restaurant struct {
+	ID          int32
+	Name        string
+	Description sql.NullString
+	Cuisine     sql.NullString
+	LogoUrl     sql.NullString
+	Address     string
+	Phone       string
+	CreatedAt   time.Time
+	Timezone    string
+	Paused      bool
+	Lat         sql.NullFloat64
+	Lng         sql.NullFloat64
+}
+
+type RestaurantHoliday struct {
+	RestaurantID int32
+	Day          time.Time
+	OpensAt      sql.NullTime
+	ClosesAt     sql.NullTime
+}
+
+type RestaurantHour struct {
+	ID           int32
+	RestaurantID int32
+	Weekday      int32
+	OpensAt      time.Time
+	ClosesAt     time.Time
+}
+
+type RestaurantStaff struct {
+	RestaurantID int32
+	UserID       int32
+	StaffRole    string
+	CreatedAt    time.Time
 }
 
 type User struct {
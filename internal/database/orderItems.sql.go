@@ -11,48 +11,118 @@ import (
 	"github.com/lib/pq"
 )
 
-const addItem = `-- name: AddItem :exec
-INSERT INTO orderitem(order_id, menu_item_id, quanity)
-VALUES (
-        $1,
-        $2,
-        $3
-       )
+const addItem = `-- name: AddItem :one
+INSERT INTO orderitem(order_id, menu_item_id, quanity, unit_price, item_name, currency, options_price)
+SELECT $1::int, menuitem.id, $2::int, menuitem.price, menuitem.name, menuitem.currency,
+       $3::bigint
+FROM menuitem
+WHERE menuitem.id = $4
+RETURNING order_id, menu_item_id, quanity, unit_price, item_name, currency, id, options_price
 `
 
 type AddItemParams struct {
-	OrderID    int32
-	MenuItemID int32
-	Quanity    int32
+	OrderID      int32
+	Quanity      int32
+	OptionsPrice int64
+	MenuItemID   int32
 }
 
-func (q *Queries) AddItem(ctx context.Context, arg AddItemParams) error {
-	_, err := q.db.ExecContext(ctx, addItem, arg.OrderID, arg.MenuItemID, arg.Quanity)
-	return err
+func (q *Queries) AddItem(ctx context.Context, arg AddItemParams) (Orderitem, error) {
+	row := q.db.QueryRowContext(ctx, addItem,
+		arg.OrderID,
+		arg.Quanity,
+		arg.OptionsPrice,
+		arg.MenuItemID,
+	)
+	var i Orderitem
+	err := row.Scan(
+		&i.OrderID,
+		&i.MenuItemID,
+		&i.Quanity,
+		&i.UnitPrice,
+		&i.ItemName,
+		&i.Currency,
+		&i.ID,
+		&i.OptionsPrice,
+	)
+	return i, err
 }
 
-const addItems = `-- name: AddItems :many
-INSERT INTO orderitem(order_id, menu_item_id, quanity)
-SELECT unnest($1::int[]), unnest($2::int[]), unnest($3::int[])
-RETURNING order_id, menu_item_id, quanity
+const addItemOptions = `-- name: AddItemOptions :many
+INSERT INTO orderitem_options(orderitem_id, option_id, group_name, option_name, price_delta, currency)
+SELECT $1::int, options.id, option_groups.name, options.name, options.price_delta,
+       $2::text
+FROM options
+         JOIN option_groups ON options.group_id = option_groups.id
+WHERE options.id = ANY($3::int[])
+ORDER BY option_groups.position, option_groups.id, options.position, options.id
+RETURNING id, orderitem_id, option_id, group_name, option_name, price_delta, currency
 `
 
-type AddItemsParams struct {
-	Column1 []int32
-	Column2 []int32
-	Column3 []int32
+type AddItemOptionsParams struct {
+	OrderitemID int32
+	Currency    string
+	OptionIds   []int32
 }
 
-func (q *Queries) AddItems(ctx context.Context, arg AddItemsParams) ([]Orderitem, error) {
-	rows, err := q.db.QueryContext(ctx, addItems, pq.Array(arg.Column1), pq.Array(arg.Column2), pq.Array(arg.Column3))
+func (q *Queries) AddItemOptions(ctx context.Context, arg AddItemOptionsParams) ([]OrderitemOption, error) {
+	rows, err := q.db.QueryContext(ctx, addItemOptions, arg.OrderitemID, arg.Currency, pq.Array(arg.OptionIds))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Orderitem
+	var items []OrderitemOption
 	for rows.Next() {
-		var i Orderitem
-		if err := rows.Scan(&i.OrderID, &i.MenuItemID, &i.Quanity); err != nil {
+		var i OrderitemOption
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderitemID,
+			&i.OptionID,
+			&i.GroupName,
+			&i.OptionName,
+			&i.PriceDelta,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrderItemOptions = `-- name: GetOrderItemOptions :many
+SELECT orderitem_options.id, orderitem_options.orderitem_id, orderitem_options.option_id, orderitem_options.group_name,
+       orderitem_options.option_name, orderitem_options.price_delta, orderitem_options.currency
+FROM orderitem_options
+         JOIN orderitem ON orderitem_options.orderitem_id = orderitem.id
+WHERE orderitem.order_id = ANY($1::int[])
+ORDER BY orderitem_options.orderitem_id, orderitem_options.id
+`
+
+func (q *Queries) GetOrderItemOptions(ctx context.Context, dollar_1 []int32) ([]OrderitemOption, error) {
+	rows, err := q.db.QueryContext(ctx, getOrderItemOptions, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderitemOption
+	for rows.Next() {
+		var i OrderitemOption
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderitemID,
+			&i.OptionID,
+			&i.GroupName,
+			&i.OptionName,
+			&i.PriceDelta,
+			&i.Currency,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
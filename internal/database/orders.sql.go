@@ -11,25 +11,38 @@ import (
 )
 
 const createOrder = `-- name: CreateOrder :one
-INSERT INTO orders(customerid, restaurantid, address, status, created_at)
+INSERT INTO orders(customerid, restaurantid, address, address_id, delivery_lat, delivery_lng, status, created_at)
 VALUES (
         $1,
         $2,
         $3,
+        $4,
+        $5,
+        $6,
         'pending',
         NOW()
 )
-RETURNING id, customerid, restaurantid, courierid, status, created_at, address
+RETURNING id, customerid, restaurantid, courierid, status, created_at, address, subtotal, total, currency, address_id, delivery_lat, delivery_lng, delivery_zone_id, delivery_fee
 `
 
 type CreateOrderParams struct {
 	Customerid   int32
 	Restaurantid int32
 	Address      string
+	AddressID    sql.NullInt32
+	DeliveryLat  sql.NullFloat64
+	DeliveryLng  sql.NullFloat64
 }
 
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
-	row := q.db.QueryRowContext(ctx, createOrder, arg.Customerid, arg.Restaurantid, arg.Address)
+	row := q.db.QueryRowContext(ctx, createOrder,
+		arg.Customerid,
+		arg.Restaurantid,
+		arg.Address,
+		arg.AddressID,
+		arg.DeliveryLat,
+		arg.DeliveryLng,
+	)
 	var i Order
 	err := row.Scan(
 		&i.ID,
@@ -39,13 +52,42 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order
 		&i.Status,
 		&i.CreatedAt,
 		&i.Address,
+		&i.Subtotal,
+		&i.Total,
+		&i.Currency,
+		&i.AddressID,
+		&i.DeliveryLat,
+		&i.DeliveryLng,
+		&i.DeliveryZoneID,
+		&i.DeliveryFee,
 	)
 	return i, err
 }
 
+const dropCourier = `-- name: DropCourier :execrows
+UPDATE orders
+SET courierid = NULL
+WHERE orders.id = $1
+  AND orders.courierid = $2
+  AND orders.status IN ('accepted', 'preparing', 'ready_for_pickup')
+`
+
+type DropCourierParams struct {
+	ID        int32
+	Courierid sql.NullInt32
+}
+
+func (q *Queries) DropCourier(ctx context.Context, arg DropCourierParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, dropCourier, arg.ID, arg.Courierid)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getCurrentIDOrderForCourier = `-- name: GetCurrentIDOrderForCourier :one
 SELECT id FROM orders
-WHERE status = 'delivering' AND courierid=$1
+WHERE status IN ('accepted', 'preparing', 'ready_for_pickup', 'delivering') AND courierid=$1
 `
 
 func (q *Queries) GetCurrentIDOrderForCourier(ctx context.Context, courierid sql.NullInt32) (int32, error) {
@@ -61,25 +103,28 @@ SELECT
     orders.status,
     orders.created_at,
     orders.address AS delivery_address,
+    orders.total,
+    orders.currency,
 
     orderitem.menu_item_id,
     orderitem.quanity,
-
-    menuitem.name AS menu_item_name,
-    menuitem.price,
+    orderitem.item_name AS menu_item_name,
+    orderitem.unit_price AS price,
+    orderitem.id AS order_item_id,
+    orderitem.options_price,
 
     restaurants.address AS restaurant_address,
-    restaurants.user_name AS restaurant_name,
+    restaurants.name AS restaurant_name,
     restaurants.phone AS restaurant_phone,
 
     customer.phone AS customer_phone
 
 FROM orders
          JOIN orderitem ON orders.id = orderitem.order_id
-         JOIN menuitem ON orderitem.menu_item_id = menuitem.id
-         JOIN users AS restaurants ON orders.restaurantid = restaurants.id
+         JOIN restaurants ON orders.restaurantid = restaurants.id
          JOIN users AS customer ON orders.customerid = customer.id
-WHERE orders.status = 'delivering' AND orders.courierid = $1
+WHERE orders.status IN ('accepted', 'preparing', 'ready_for_pickup', 'delivering') AND orders.courierid = $1
+ORDER BY orderitem.id
 `
 
 type GetCurrentOrderForCourierRow struct {
@@ -87,12 +132,16 @@ type GetCurrentOrderForCourierRow struct {
 	Status            string
 	CreatedAt         sql.NullTime
 	DeliveryAddress   string
+	Total             int64
+	Currency          string
 	MenuItemID        int32
 	Quanity           int32
 	MenuItemName      string
-	Price             float64
-	RestaurantAddress sql.NullString
-	RestaurantName    sql.NullString
+	Price             int64
+	OrderItemID       int32
+	OptionsPrice      int64
+	RestaurantAddress string
+	RestaurantName    string
 	RestaurantPhone   string
 	CustomerPhone     string
 }
@@ -111,10 +160,14 @@ func (q *Queries) GetCurrentOrderForCourier(ctx context.Context, courierid sql.N
 			&i.Status,
 			&i.CreatedAt,
 			&i.DeliveryAddress,
+			&i.Total,
+			&i.Currency,
 			&i.MenuItemID,
 			&i.Quanity,
 			&i.MenuItemName,
 			&i.Price,
+			&i.OrderItemID,
+			&i.OptionsPrice,
 			&i.RestaurantAddress,
 			&i.RestaurantName,
 			&i.RestaurantPhone,
@@ -139,15 +192,18 @@ SELECT
     orders.status,
     orders.created_at,
     orders.address AS delivery_address,
+    orders.total,
+    orders.currency,
 
     orderitem.menu_item_id,
     orderitem.quanity,
-
-    menuitem.name AS menu_item_name,
-    menuitem.price,
+    orderitem.item_name AS menu_item_name,
+    orderitem.unit_price AS price,
+    orderitem.id AS order_item_id,
+    orderitem.options_price,
 
     restaurants.address AS restaurant_address,
-    restaurants.user_name AS restaurant_name,
+    restaurants.name AS restaurant_name,
     restaurants.phone AS restaurant_phone,
 
     customer.user_name AS costomer_name,
@@ -158,11 +214,11 @@ SELECT
 
 FROM orders
          JOIN orderitem ON orders.id = orderitem.order_id
-         JOIN menuitem ON orderitem.menu_item_id = menuitem.id
-         JOIN users AS restaurants ON orders.restaurantid = restaurants.id
+         JOIN restaurants ON orders.restaurantid = restaurants.id
          JOIN users AS customer ON orders.customerid = customer.id
         LEFT JOIN users AS courier ON orders.courierid = courier.id
 WHERE orders.id = $1
+ORDER BY orderitem.id
 `
 
 type GetFullOrderByIDRow struct {
@@ -170,12 +226,16 @@ type GetFullOrderByIDRow struct {
 	Status            string
 	CreatedAt         sql.NullTime
 	DeliveryAddress   string
+	Total             int64
+	Currency          string
 	MenuItemID        int32
 	Quanity           int32
 	MenuItemName      string
-	Price             float64
-	RestaurantAddress sql.NullString
-	RestaurantName    sql.NullString
+	Price             int64
+	OrderItemID       int32
+	OptionsPrice      int64
+	RestaurantAddress string
+	RestaurantName    string
 	RestaurantPhone   string
 	CostomerName      sql.NullString
 	CustomerPhone     string
@@ -197,10 +257,14 @@ func (q *Queries) GetFullOrderByID(ctx context.Context, id int32) ([]GetFullOrde
 			&i.Status,
 			&i.CreatedAt,
 			&i.DeliveryAddress,
+			&i.Total,
+			&i.Currency,
 			&i.MenuItemID,
 			&i.Quanity,
 			&i.MenuItemName,
 			&i.Price,
+			&i.OrderItemID,
+			&i.OptionsPrice,
 			&i.RestaurantAddress,
 			&i.RestaurantName,
 			&i.RestaurantPhone,
@@ -228,15 +292,18 @@ SELECT
     orders.status,
     orders.created_at,
     orders.address AS delivery_address,
+    orders.total,
+    orders.currency,
 
     orderitem.menu_item_id,
     orderitem.quanity,
-
-    menuitem.name AS menu_item_name,
-    menuitem.price,
+    orderitem.item_name AS menu_item_name,
+    orderitem.unit_price AS price,
+    orderitem.id AS order_item_id,
+    orderitem.options_price,
 
     restaurants.address AS restaurant_address,
-    restaurants.user_name AS restaurant_name,
+    restaurants.name AS restaurant_name,
     restaurants.phone AS restaurant_phone,
 
     customer.user_name AS costomer_name,
@@ -244,30 +311,59 @@ SELECT
 
 FROM orders
          JOIN orderitem ON orders.id = orderitem.order_id
-         JOIN menuitem ON orderitem.menu_item_id = menuitem.id
-         JOIN users AS restaurants ON orders.restaurantid = restaurants.id
+         JOIN restaurants ON orders.restaurantid = restaurants.id
          JOIN users AS customer ON orders.customerid = customer.id
-WHERE orders.customerid = $1
+WHERE orders.id IN (
+    SELECT page.id FROM orders AS page
+    WHERE page.customerid = $1
+      AND ($2::text IS NULL OR page.status = $2)
+      AND ($3::timestamp IS NULL OR page.created_at >= $3)
+      AND ($4::timestamp IS NULL OR page.created_at < $4)
+      AND ($5::int IS NULL OR page.id < $5)
+    ORDER BY page.id DESC
+    LIMIT $6
+)
+ORDER BY orders.id DESC, orderitem.id
 `
 
+type GetFullOrdersByUserIDParams struct {
+	Customerid  int32
+	Status      sql.NullString
+	CreatedFrom sql.NullTime
+	CreatedTo   sql.NullTime
+	AfterID     sql.NullInt32
+	PageLimit   int32
+}
+
 type GetFullOrdersByUserIDRow struct {
 	OrderID           int32
 	Status            string
 	CreatedAt         sql.NullTime
 	DeliveryAddress   string
+	Total             int64
+	Currency          string
 	MenuItemID        int32
 	Quanity           int32
 	MenuItemName      string
-	Price             float64
-	RestaurantAddress sql.NullString
-	RestaurantName    sql.NullString
+	Price             int64
+	OrderItemID       int32
+	OptionsPrice      int64
+	RestaurantAddress string
+	RestaurantName    string
 	RestaurantPhone   string
 	CostomerName      sql.NullString
 	CustomerPhone     string
 }
 
-func (q *Queries) GetFullOrdersByUserID(ctx context.Context, customerid int32) ([]GetFullOrdersByUserIDRow, error) {
-	rows, err := q.db.QueryContext(ctx, getFullOrdersByUserID, customerid)
+func (q *Queries) GetFullOrdersByUserID(ctx context.Context, arg GetFullOrdersByUserIDParams) ([]GetFullOrdersByUserIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFullOrdersByUserID,
+		arg.Customerid,
+		arg.Status,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.AfterID,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -280,10 +376,14 @@ func (q *Queries) GetFullOrdersByUserID(ctx context.Context, customerid int32) (
 			&i.Status,
 			&i.CreatedAt,
 			&i.DeliveryAddress,
+			&i.Total,
+			&i.Currency,
 			&i.MenuItemID,
 			&i.Quanity,
 			&i.MenuItemName,
 			&i.Price,
+			&i.OrderItemID,
+			&i.OptionsPrice,
 			&i.RestaurantAddress,
 			&i.RestaurantName,
 			&i.RestaurantPhone,
@@ -309,15 +409,18 @@ SELECT
     orders.status,
     orders.created_at,
     orders.address AS delivery_address,
+    orders.total,
+    orders.currency,
 
     orderitem.menu_item_id,
     orderitem.quanity,
-
-    menuitem.name AS menu_item_name,
-    menuitem.price,
+    orderitem.item_name AS menu_item_name,
+    orderitem.unit_price AS price,
+    orderitem.id AS order_item_id,
+    orderitem.options_price,
 
     restaurants.address AS restaurant_address,
-    restaurants.user_name AS restaurant_name,
+    restaurants.name AS restaurant_name,
     restaurants.phone AS restaurant_phone,
 
     customer.user_name AS costomer_name,
@@ -325,30 +428,57 @@ SELECT
 
 FROM orders
          JOIN orderitem ON orders.id = orderitem.order_id
-         JOIN menuitem ON orderitem.menu_item_id = menuitem.id
-         JOIN users AS restaurants ON orders.restaurantid = restaurants.id
+         JOIN restaurants ON orders.restaurantid = restaurants.id
         JOIN users AS customer ON orders.customerid = customer.id
-WHERE orders.status = 'pending'
+WHERE orders.id IN (
+    SELECT page.id FROM orders AS page
+    WHERE page.status IN ('accepted', 'preparing', 'ready_for_pickup') AND page.courierid IS NULL
+      AND ($1::text IS NULL OR page.status = $1)
+      AND ($2::timestamp IS NULL OR page.created_at >= $2)
+      AND ($3::timestamp IS NULL OR page.created_at < $3)
+      AND ($4::int IS NULL OR page.id < $4)
+    ORDER BY page.id DESC
+    LIMIT $5
+)
+ORDER BY orders.id DESC, orderitem.id
 `
 
+type GetFullPendingOrdersParams struct {
+	Status      sql.NullString
+	CreatedFrom sql.NullTime
+	CreatedTo   sql.NullTime
+	AfterID     sql.NullInt32
+	PageLimit   int32
+}
+
 type GetFullPendingOrdersRow struct {
 	OrderID           int32
 	Status            string
 	CreatedAt         sql.NullTime
 	DeliveryAddress   string
+	Total             int64
+	Currency          string
 	MenuItemID        int32
 	Quanity           int32
 	MenuItemName      string
-	Price             float64
-	RestaurantAddress sql.NullString
-	RestaurantName    sql.NullString
+	Price             int64
+	OrderItemID       int32
+	OptionsPrice      int64
+	RestaurantAddress string
+	RestaurantName    string
 	RestaurantPhone   string
 	CostomerName      sql.NullString
 	CustomerPhone     string
 }
 
-func (q *Queries) GetFullPendingOrders(ctx context.Context) ([]GetFullPendingOrdersRow, error) {
-	rows, err := q.db.QueryContext(ctx, getFullPendingOrders)
+func (q *Queries) GetFullPendingOrders(ctx context.Context, arg GetFullPendingOrdersParams) ([]GetFullPendingOrdersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFullPendingOrders,
+		arg.Status,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.AfterID,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -361,10 +491,14 @@ func (q *Queries) GetFullPendingOrders(ctx context.Context) ([]GetFullPendingOrd
 			&i.Status,
 			&i.CreatedAt,
 			&i.DeliveryAddress,
+			&i.Total,
+			&i.Currency,
 			&i.MenuItemID,
 			&i.Quanity,
 			&i.MenuItemName,
 			&i.Price,
+			&i.OrderItemID,
+			&i.OptionsPrice,
 			&i.RestaurantAddress,
 			&i.RestaurantName,
 			&i.RestaurantPhone,
@@ -384,6 +518,34 @@ func (q *Queries) GetFullPendingOrders(ctx context.Context) ([]GetFullPendingOrd
 	return items, nil
 }
 
+const getOrderByID = `-- name: GetOrderByID :one
+SELECT id, customerid, restaurantid, courierid, status, created_at, address, subtotal, total, currency, address_id, delivery_lat, delivery_lng, delivery_zone_id, delivery_fee FROM orders
+WHERE orders.id = $1
+`
+
+func (q *Queries) GetOrderByID(ctx context.Context, id int32) (Order, error) {
+	row := q.db.QueryRowContext(ctx, getOrderByID, id)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.Customerid,
+		&i.Restaurantid,
+		&i.Courierid,
+		&i.Status,
+		&i.CreatedAt,
+		&i.Address,
+		&i.Subtotal,
+		&i.Total,
+		&i.Currency,
+		&i.AddressID,
+		&i.DeliveryLat,
+		&i.DeliveryLng,
+		&i.DeliveryZoneID,
+		&i.DeliveryFee,
+	)
+	return i, err
+}
+
 const getOrderStatusByID = `-- name: GetOrderStatusByID :one
 SELECT orders.status FROM orders
 WHERE orders.id = $1
@@ -396,28 +558,159 @@ func (q *Queries) GetOrderStatusByID(ctx context.Context, id int32) (string, err
 	return status, err
 }
 
+const getUndispatchedReadyOrderIDs = `-- name: GetUndispatchedReadyOrderIDs :many
+SELECT id FROM orders
+WHERE status = 'ready_for_pickup' AND courierid IS NULL
+ORDER BY id
+`
+
+func (q *Queries) GetUndispatchedReadyOrderIDs(ctx context.Context) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, getUndispatchedReadyOrderIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setOrderStatus = `-- name: SetOrderStatus :one
+UPDATE orders
+SET status = $1
+WHERE orders.id = $2 AND orders.status = $3
+RETURNING id, customerid, restaurantid, courierid, status, created_at, address, subtotal, total, currency, address_id, delivery_lat, delivery_lng, delivery_zone_id, delivery_fee
+`
+
+type SetOrderStatusParams struct {
+	NewStatus string
+	ID        int32
+	OldStatus string
+}
+
+func (q *Queries) SetOrderStatus(ctx context.Context, arg SetOrderStatusParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, setOrderStatus, arg.NewStatus, arg.ID, arg.OldStatus)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.Customerid,
+		&i.Restaurantid,
+		&i.Courierid,
+		&i.Status,
+		&i.CreatedAt,
+		&i.Address,
+		&i.Subtotal,
+		&i.Total,
+		&i.Currency,
+		&i.AddressID,
+		&i.DeliveryLat,
+		&i.DeliveryLng,
+		&i.DeliveryZoneID,
+		&i.DeliveryFee,
+	)
+	return i, err
+}
+
+const setOrderTotals = `-- name: SetOrderTotals :one
+UPDATE orders
+SET subtotal = $2,
+    delivery_fee = $3,
+    total = $4,
+    currency = $5,
+    delivery_zone_id = $6
+WHERE orders.id = $1
+RETURNING id, customerid, restaurantid, courierid, status, created_at, address, subtotal, total, currency, address_id, delivery_lat, delivery_lng, delivery_zone_id, delivery_fee
+`
+
+type SetOrderTotalsParams struct {
+	ID             int32
+	Subtotal       int64
+	DeliveryFee    int64
+	Total          int64
+	Currency       string
+	DeliveryZoneID sql.NullInt32
+}
+
+func (q *Queries) SetOrderTotals(ctx context.Context, arg SetOrderTotalsParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, setOrderTotals,
+		arg.ID,
+		arg.Subtotal,
+		arg.DeliveryFee,
+		arg.Total,
+		arg.Currency,
+		arg.DeliveryZoneID,
+	)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.Customerid,
+		&i.Restaurantid,
+		&i.Courierid,
+		&i.Status,
+		&i.CreatedAt,
+		&i.Address,
+		&i.Subtotal,
+		&i.Total,
+		&i.Currency,
+		&i.AddressID,
+		&i.DeliveryLat,
+		&i.DeliveryLng,
+		&i.DeliveryZoneID,
+		&i.DeliveryFee,
+	)
+	return i, err
+}
+
+const unassignCourier = `-- name: UnassignCourier :exec
+UPDATE orders
+SET courierid = NULL
+WHERE orders.id = $1
+`
+
+func (q *Queries) UnassignCourier(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, unassignCourier, id)
+	return err
+}
+
 const updateCourierID = `-- name: UpdateCourierID :many
 WITH updated_order AS (
+    -- only one of concurrent assigns can match, the others get no rows
     UPDATE orders
-    SET courierid = $1,
-        status = 'delivering'
+    SET courierid = $1
     WHERE orders.id = $2
-    RETURNING id, customerid, restaurantid, courierid, status, created_at, address
+      AND orders.courierid IS NULL
+      AND orders.status IN ('accepted', 'preparing', 'ready_for_pickup')
+    RETURNING id, customerid, restaurantid, courierid, status, created_at, address, subtotal, total, currency, address_id, delivery_lat, delivery_lng, delivery_zone_id, delivery_fee
 )
 SELECT
     o.id AS order_id,
     o.status,
     o.created_at,
     o.address AS delivery_address,
+    o.total,
+    o.currency,
 
     orderitem.menu_item_id,
     orderitem.quanity,
-
-    menuitem.name AS menu_item_name,
-    menuitem.price,
+    orderitem.item_name AS menu_item_name,
+    orderitem.unit_price AS price,
+    orderitem.id AS order_item_id,
+    orderitem.options_price,
 
     restaurants.address AS restaurant_address,
-    restaurants.user_name AS restaurant_name,
+    restaurants.name AS restaurant_name,
     restaurants.phone AS restaurant_phone,
 
     customer.user_name AS costomer_name,
@@ -428,10 +721,10 @@ SELECT
 
 FROM updated_order o
          JOIN orderitem ON o.id = orderitem.order_id
-         JOIN menuitem ON orderitem.menu_item_id = menuitem.id
-         JOIN users AS restaurants ON o.restaurantid = restaurants.id
+         JOIN restaurants ON o.restaurantid = restaurants.id
          JOIN users AS customer ON o.customerid = customer.id
          LEFT JOIN users AS courier ON o.courierid = courier.id
+ORDER BY orderitem.id
 `
 
 type UpdateCourierIDParams struct {
@@ -444,12 +737,16 @@ type UpdateCourierIDRow struct {
 	Status            string
 	CreatedAt         sql.NullTime
 	DeliveryAddress   string
+	Total             int64
+	Currency          string
 	MenuItemID        int32
 	Quanity           int32
 	MenuItemName      string
-	Price             float64
-	RestaurantAddress sql.NullString
-	RestaurantName    sql.NullString
+	Price             int64
+	OrderItemID       int32
+	OptionsPrice      int64
+	RestaurantAddress string
+	RestaurantName    string
 	RestaurantPhone   string
 	CostomerName      sql.NullString
 	CustomerPhone     string
@@ -471,10 +768,14 @@ func (q *Queries) UpdateCourierID(ctx context.Context, arg UpdateCourierIDParams
 			&i.Status,
 			&i.CreatedAt,
 			&i.DeliveryAddress,
+			&i.Total,
+			&i.Currency,
 			&i.MenuItemID,
 			&i.Quanity,
 			&i.MenuItemName,
 			&i.Price,
+			&i.OrderItemID,
+			&i.OptionsPrice,
 			&i.RestaurantAddress,
 			&i.RestaurantName,
 			&i.RestaurantPhone,
@@ -496,11 +797,12 @@ func (q *Queries) UpdateCourierID(ctx context.Context, arg UpdateCourierIDParams
 	return items, nil
 }
 
-const updateOrderStatus = `-- name: UpdateOrderStatus :exec
+const updateOrderStatus = `-- name: UpdateOrderStatus :execrows
 UPDATE orders
-SET courierid = $1,
-    status = 'delivered'
+SET status = 'delivered'
 WHERE orders.id = $2
+  AND orders.courierid = $1
+  AND orders.status = 'delivering'
 `
 
 type UpdateOrderStatusParams struct {
@@ -508,7 +810,10 @@ type UpdateOrderStatusParams struct {
 	ID        int32
 }
 
-func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) error {
-	_, err := q.db.ExecContext(ctx, updateOrderStatus, arg.Courierid, arg.ID)
-	return err
+func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateOrderStatus, arg.Courierid, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
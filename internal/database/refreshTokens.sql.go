@@ -11,20 +11,25 @@ import (
 )
 
 const createToken = `-- name: CreateToken :one
-INSERT INTO refreshTokens (token, created_at, updated_at, user_id, expires_at)
+INSERT INTO refreshTokens (token, created_at, updated_at, user_id, expires_at, family, user_agent, ip)
 VALUES (
     $1,
         NOW(),
         NOW(),
         $2,
-        NOW() + INTERVAL '10 days'
+        NOW() + INTERVAL '10 days',
+        $1,
+        $3,
+        $4
 )
 RETURNING token, expires_at
 `
 
 type CreateTokenParams struct {
-	Token  string
-	UserID int32
+	Token     string
+	UserID    int32
+	UserAgent sql.NullString
+	Ip        sql.NullString
 }
 
 type CreateTokenRow struct {
@@ -33,14 +38,125 @@ type CreateTokenRow struct {
 }
 
 func (q *Queries) CreateToken(ctx context.Context, arg CreateTokenParams) (CreateTokenRow, error) {
-	row := q.db.QueryRowContext(ctx, createToken, arg.Token, arg.UserID)
+	row := q.db.QueryRowContext(ctx, createToken,
+		arg.Token,
+		arg.UserID,
+		arg.UserAgent,
+		arg.Ip,
+	)
 	var i CreateTokenRow
 	err := row.Scan(&i.Token, &i.ExpiresAt)
 	return i, err
 }
 
+const createTokenInFamily = `-- name: CreateTokenInFamily :one
+INSERT INTO refreshTokens (token, created_at, updated_at, user_id, expires_at, family, user_agent, ip)
+VALUES (
+        $1,
+        NOW(),
+        NOW(),
+        $2,
+        NOW() + INTERVAL '10 days',
+        $3,
+        $4,
+        $5
+)
+RETURNING token, expires_at
+`
+
+type CreateTokenInFamilyParams struct {
+	Token     string
+	UserID    int32
+	Family    string
+	UserAgent sql.NullString
+	Ip        sql.NullString
+}
+
+type CreateTokenInFamilyRow struct {
+	Token     string
+	ExpiresAt sql.NullTime
+}
+
+func (q *Queries) CreateTokenInFamily(ctx context.Context, arg CreateTokenInFamilyParams) (CreateTokenInFamilyRow, error) {
+	row := q.db.QueryRowContext(ctx, createTokenInFamily,
+		arg.Token,
+		arg.UserID,
+		arg.Family,
+		arg.UserAgent,
+		arg.Ip,
+	)
+	var i CreateTokenInFamilyRow
+	err := row.Scan(&i.Token, &i.ExpiresAt)
+	return i, err
+}
+
+const getActiveTokensByUser = `-- name: GetActiveTokensByUser :many
+SELECT token, created_at, updated_at, user_id, expires_at, revoked_at, family, replaced_by, user_agent, ip, id FROM refreshtokens
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+ORDER BY created_at DESC
+`
+
+func (q *Queries) GetActiveTokensByUser(ctx context.Context, userID int32) ([]Refreshtoken, error) {
+	rows, err := q.db.QueryContext(ctx, getActiveTokensByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Refreshtoken
+	for rows.Next() {
+		var i Refreshtoken
+		if err := rows.Scan(
+			&i.Token,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UserID,
+			&i.ExpiresAt,
+			&i.RevokedAt,
+			&i.Family,
+			&i.ReplacedBy,
+			&i.UserAgent,
+			&i.Ip,
+			&i.ID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRefreshToken = `-- name: GetRefreshToken :one
+SELECT token, created_at, updated_at, user_id, expires_at, revoked_at, family, replaced_by, user_agent, ip, id FROM refreshtokens
+WHERE token = $1
+`
+
+func (q *Queries) GetRefreshToken(ctx context.Context, token string) (Refreshtoken, error) {
+	row := q.db.QueryRowContext(ctx, getRefreshToken, token)
+	var i Refreshtoken
+	err := row.Scan(
+		&i.Token,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.ExpiresAt,
+		&i.RevokedAt,
+		&i.Family,
+		&i.ReplacedBy,
+		&i.UserAgent,
+		&i.Ip,
+		&i.ID,
+	)
+	return i, err
+}
+
 const getTokensByUser = `-- name: GetTokensByUser :many
-SELECT token, created_at, updated_at, user_id, expires_at, revoked_at FROM refreshtokens
+SELECT token, created_at, updated_at, user_id, expires_at, revoked_at, family, replaced_by, user_agent, ip, id FROM refreshtokens
 WHERE user_id = $1
 `
 
@@ -60,6 +176,11 @@ func (q *Queries) GetTokensByUser(ctx context.Context, userID int32) ([]Refresht
 			&i.UserID,
 			&i.ExpiresAt,
 			&i.RevokedAt,
+			&i.Family,
+			&i.ReplacedBy,
+			&i.UserAgent,
+			&i.Ip,
+			&i.ID,
 		); err != nil {
 			return nil, err
 		}
@@ -85,3 +206,92 @@ func (q *Queries) GetUserByToken(ctx context.Context, token string) (int32, erro
 	err := row.Scan(&user_id)
 	return user_id, err
 }
+
+const revokeSession = `-- name: RevokeSession :execrows
+UPDATE refreshtokens
+SET revoked_at = NOW(),
+    updated_at = NOW()
+WHERE user_id = $2 AND revoked_at IS NULL
+  AND family = (SELECT family FROM refreshtokens WHERE id = $1 AND user_id = $2)
+`
+
+type RevokeSessionParams struct {
+	ID     int64
+	UserID int32
+}
+
+func (q *Queries) RevokeSession(ctx context.Context, arg RevokeSessionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeSession, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const revokeToken = `-- name: RevokeToken :execrows
+UPDATE refreshtokens
+SET revoked_at = NOW(),
+    updated_at = NOW()
+WHERE token = $1 AND user_id = $2 AND revoked_at IS NULL
+`
+
+type RevokeTokenParams struct {
+	Token  string
+	UserID int32
+}
+
+func (q *Queries) RevokeToken(ctx context.Context, arg RevokeTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeToken, arg.Token, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const revokeTokenFamily = `-- name: RevokeTokenFamily :exec
+UPDATE refreshtokens
+SET revoked_at = NOW(),
+    updated_at = NOW()
+WHERE family = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeTokenFamily(ctx context.Context, family string) error {
+	_, err := q.db.ExecContext(ctx, revokeTokenFamily, family)
+	return err
+}
+
+const revokeTokensByUser = `-- name: RevokeTokensByUser :execrows
+UPDATE refreshtokens
+SET revoked_at = NOW(),
+    updated_at = NOW()
+WHERE user_id = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) RevokeTokensByUser(ctx context.Context, userID int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeTokensByUser, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const rotateToken = `-- name: RotateToken :execrows
+UPDATE refreshtokens
+SET revoked_at = NOW(),
+    updated_at = NOW(),
+    replaced_by = $2
+WHERE token = $1 AND revoked_at IS NULL
+`
+
+type RotateTokenParams struct {
+	Token      string
+	ReplacedBy sql.NullString
+}
+
+func (q *Queries) RotateToken(ctx context.Context, arg RotateTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, rotateToken, arg.Token, arg.ReplacedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,243 @@
+package dispatch
+
+import (
+	"context"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/orderStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Offer statuses stored in courier_offers.
+const (
+	OfferOffered  = "offered"
+	OfferAccepted = "accepted"
+	OfferDeclined = "declined"
+	OfferExpired  = "expired"
+)
+
+// Reasons an offer is withdrawn from the courier before they answered.
+const (
+	WithdrawnTaken     = "taken"
+	WithdrawnCancelled = "cancelled"
+	WithdrawnExpired   = "expired"
+)
+
+type store interface {
+	GetOrderByID(ctx context.Context, id int32) (database.Order, error)
+	GetDispatchCandidates(ctx context.Context, orderID int32) ([]database.GetDispatchCandidatesRow, error)
+	CreateCourierOffer(ctx context.Context, arg database.CreateCourierOfferParams) (database.CourierOffer, error)
+	GetCourierOfferByID(ctx context.Context, id int32) (database.CourierOffer, error)
+	ExpireCourierOffer(ctx context.Context, id int32) (int64, error)
+}
+
+// Notifier pushes offer changes to the couriers they concern.
+type Notifier interface {
+	OfferCreated(ctx context.Context, offer database.CourierOffer)
+	OfferWithdrawn(offer database.CourierOffer, reason string)
+	CourierAssigned(courierID, orderID int32, status string)
+}
+
+// Dispatcher offers a ready order to one online courier at a time. A courier who declines, drops the order
+// or lets the offer time out is skipped and the next one is asked. When nobody is left the order
+// stays in GET /orders/pending, where couriers can still take it manually, until a courier comes online
+// and dispatching starts over.
+//
+// Dispatching lives in memory: orders whose dispatch was interrupted by a restart also fall back
+// to manual assignment.
+type Dispatcher struct {
+	log      *slog.Logger
+	store    store
+	strategy Strategy
+	notifier Notifier
+	timeout  time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu sync.Mutex
+	// running holds the orders being dispatched, the channel wakes the loop when a courier answers.
+	running map[int32]chan struct{}
+}
+
+// New creates a dispatcher, offers live for timeout rounded down to whole seconds and at least a second.
+func New(log *slog.Logger, store store, strategy Strategy, notifier Notifier, timeout time.Duration) *Dispatcher {
+	timeout = max(timeout.Truncate(time.Second), time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		log:      log,
+		store:    store,
+		strategy: strategy,
+		notifier: notifier,
+		timeout:  timeout,
+		ctx:      ctx,
+		cancel:   cancel,
+		running:  make(map[int32]chan struct{}),
+	}
+}
+
+// Dispatch starts offering the order to couriers in the background. Calling it for an order
+// that is already being dispatched does nothing.
+func (d *Dispatcher) Dispatch(orderID int32) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.ctx.Err() != nil {
+		return
+	}
+	if _, ok := d.running[orderID]; ok {
+		return
+	}
+	answered := make(chan struct{}, 1)
+	d.running[orderID] = answered
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer d.finish(orderID)
+		d.run(orderID, answered)
+	}()
+}
+
+// Answered tells the dispatcher that the courier responded to the current offer of the order,
+// so it does not wait for the timeout.
+func (d *Dispatcher) Answered(orderID int32) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	answered, ok := d.running[orderID]
+	if !ok {
+		return
+	}
+	select {
+	case answered <- struct{}{}:
+	default:
+	}
+}
+
+// Assigned confirms the assignment to the courier and wakes the dispatch of the order,
+// so an offer still open for another courier is withdrawn right away.
+func (d *Dispatcher) Assigned(orderID, courierID int32, status string) {
+	d.notifier.CourierAssigned(courierID, orderID, status)
+	d.Answered(orderID)
+}
+
+// Stop cancels all dispatches and waits for them to return. Open offers expire on their own.
+func (d *Dispatcher) Stop() {
+	d.mu.Lock()
+	d.cancel()
+	d.mu.Unlock()
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) finish(orderID int32) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.running, orderID)
+}
+
+func (d *Dispatcher) run(orderID int32, answered chan struct{}) {
+	const op = "dispatch.run"
+	log := d.log.With(slog.String("op", op), slog.Int("order_id", int(orderID)))
+
+	for {
+		order, err := d.store.GetOrderByID(d.ctx, orderID)
+		if err != nil {
+			log.Error("failed to get order", sl.Err(err))
+			return
+		}
+		// taken manually, cancelled or picked up in the meantime
+		if order.Courierid.Valid || !orderStatus.CanAssignCourier(orderStatus.Status(order.Status)) {
+			log.Info("order no longer needs a courier", slog.String("status", order.Status))
+			return
+		}
+
+		rows, err := d.store.GetDispatchCandidates(d.ctx, orderID)
+		if err != nil {
+			log.Error("failed to get couriers", sl.Err(err))
+			return
+		}
+		if len(rows) == 0 {
+			log.Info("no couriers left to offer, order stays pending")
+			return
+		}
+		candidates := make([]Courier, 0, len(rows))
+		for _, row := range rows {
+			candidates = append(candidates, Courier{ID: row.ID, OrderCount: row.OrderCount})
+		}
+		ranked, err := d.strategy.Rank(d.ctx, orderID, candidates)
+		if err != nil {
+			log.Error("failed to rank couriers", sl.Err(err))
+			return
+		}
+
+		// drop a late answer to the previous offer
+		select {
+		case <-answered:
+		default:
+		}
+
+		offer, err := d.store.CreateCourierOffer(d.ctx, database.CreateCourierOfferParams{
+			OrderID:        orderID,
+			CourierID:      ranked[0].ID,
+			TimeoutSeconds: int32(d.timeout / time.Second),
+		})
+		if err != nil {
+			log.Error("failed to create offer", sl.Err(err))
+			return
+		}
+		log.Info("order offered", slog.Int("courier_id", int(offer.CourierID)))
+		d.notifier.OfferCreated(d.ctx, offer)
+
+		timer := time.NewTimer(d.timeout)
+		select {
+		case <-answered:
+		case <-timer.C:
+		case <-d.ctx.Done():
+			timer.Stop()
+			return
+		}
+		timer.Stop()
+
+		// the courier may answer right as the timer fires, so the stored offer decides
+		expired, err := d.store.ExpireCourierOffer(d.ctx, offer.ID)
+		if err != nil {
+			log.Error("failed to expire offer", sl.Err(err))
+			return
+		}
+		offer, err = d.store.GetCourierOfferByID(d.ctx, offer.ID)
+		if err != nil {
+			log.Error("failed to get offer", sl.Err(err))
+			return
+		}
+		if offer.Status == OfferAccepted {
+			log.Info("offer accepted", slog.Int("courier_id", int(offer.CourierID)))
+			return
+		}
+		// the courier did not answer, declines and going offline close the offer on their side
+		if expired > 0 {
+			d.notifier.OfferWithdrawn(offer, d.withdrawnReason(orderID))
+		}
+		log.Info("offer not accepted, trying next courier",
+			slog.Int("courier_id", int(offer.CourierID)),
+			slog.String("offer_status", offer.Status))
+	}
+}
+
+// withdrawnReason tells why an offer of the order closed without an answer.
+func (d *Dispatcher) withdrawnReason(orderID int32) string {
+	order, err := d.store.GetOrderByID(d.ctx, orderID)
+	if err != nil {
+		return WithdrawnExpired
+	}
+	if order.Courierid.Valid {
+		return WithdrawnTaken
+	}
+	if !orderStatus.CanAssignCourier(orderStatus.Status(order.Status)) {
+		return WithdrawnCancelled
+	}
+	return WithdrawnExpired
+}
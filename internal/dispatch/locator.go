@@ -0,0 +1,70 @@
+package dispatch
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/geocode"
+)
+
+type locatorStore interface {
+	GetOrderByID(ctx context.Context, id int32) (database.Order, error)
+	GetRestaurantByID(ctx context.Context, id int32) (database.Restaurant, error)
+	SetRestaurantLocation(ctx context.Context, arg database.SetRestaurantLocationParams) error
+	GetCourierDistances(ctx context.Context, arg database.GetCourierDistancesParams) ([]database.GetCourierDistancesRow, error)
+}
+
+// CourierLocator measures from the last reported location of each courier to the restaurant of the order.
+// A restaurant is put on the map by geocoding its address the first time one of its orders is dispatched.
+type CourierLocator struct {
+	store    locatorStore
+	geocoder geocode.Geocoder
+}
+
+func NewLocator(store locatorStore, geocoder geocode.Geocoder) *CourierLocator {
+	return &CourierLocator{store: store, geocoder: geocoder}
+}
+
+func (l *CourierLocator) Distances(ctx context.Context, orderID int32, courierIDs []int32) (map[int32]float64, error) {
+	order, err := l.store.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("get order: %w", err)
+	}
+	restaurant, err := l.store.GetRestaurantByID(ctx, order.Restaurantid)
+	if err != nil {
+		return nil, fmt.Errorf("get restaurant: %w", err)
+	}
+
+	if !restaurant.Lat.Valid || !restaurant.Lng.Valid {
+		point, err := l.geocoder.Geocode(ctx, restaurant.Address)
+		// an address the geocoder can't find leaves every courier at unknown distance
+		if errors.Is(err, geocode.ErrNotFound) {
+			return map[int32]float64{}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("locate restaurant: %w", err)
+		}
+		if err := l.store.SetRestaurantLocation(ctx, database.SetRestaurantLocationParams{
+			ID:  restaurant.ID,
+			Lat: sql.NullFloat64{Float64: point.Lat, Valid: true},
+			Lng: sql.NullFloat64{Float64: point.Lng, Valid: true},
+		}); err != nil {
+			return nil, fmt.Errorf("save restaurant location: %w", err)
+		}
+	}
+
+	rows, err := l.store.GetCourierDistances(ctx, database.GetCourierDistancesParams{
+		RestaurantID: restaurant.ID,
+		CourierIds:   courierIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get distances: %w", err)
+	}
+	distances := make(map[int32]float64, len(rows))
+	for _, row := range rows {
+		distances[row.CourierID] = row.DistanceM
+	}
+	return distances, nil
+}
@@ -0,0 +1,102 @@
+package dispatch
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"github.com/yourgfslove/GodFoodApi/internal/geocode"
+	"reflect"
+	"testing"
+)
+
+type fakeLocatorStore struct {
+	restaurant database.Restaurant
+	distances  map[int32]float64
+	saved      *database.SetRestaurantLocationParams
+}
+
+func (s *fakeLocatorStore) GetOrderByID(_ context.Context, id int32) (database.Order, error) {
+	return database.Order{ID: id, Restaurantid: s.restaurant.ID}, nil
+}
+
+func (s *fakeLocatorStore) GetRestaurantByID(_ context.Context, _ int32) (database.Restaurant, error) {
+	return s.restaurant, nil
+}
+
+func (s *fakeLocatorStore) SetRestaurantLocation(_ context.Context, arg database.SetRestaurantLocationParams) error {
+	s.saved = &arg
+	s.restaurant.Lat, s.restaurant.Lng = arg.Lat, arg.Lng
+	return nil
+}
+
+func (s *fakeLocatorStore) GetCourierDistances(_ context.Context, arg database.GetCourierDistancesParams) ([]database.GetCourierDistancesRow, error) {
+	if !s.restaurant.Lat.Valid {
+		return nil, nil
+	}
+	var rows []database.GetCourierDistancesRow
+	for _, id := range arg.CourierIds {
+		if d, ok := s.distances[id]; ok {
+			rows = append(rows, database.GetCourierDistancesRow{CourierID: id, DistanceM: d})
+		}
+	}
+	return rows, nil
+}
+
+type fakeGeocoder struct {
+	point geo.Point
+	err   error
+}
+
+func (g fakeGeocoder) Geocode(_ context.Context, _ string) (geo.Point, error) {
+	return g.point, g.err
+}
+
+func TestCourierLocator(t *testing.T) {
+	located := database.Restaurant{ID: 1, Address: "Tverskaya 1",
+		Lat: sql.NullFloat64{Float64: 55.75, Valid: true}, Lng: sql.NullFloat64{Float64: 37.61, Valid: true}}
+	unlocated := database.Restaurant{ID: 1, Address: "Tverskaya 1"}
+	distances := map[int32]float64{1: 1200, 2: 300}
+
+	testcases := []struct {
+		name       string
+		restaurant database.Restaurant
+		geocoder   fakeGeocoder
+		want       map[int32]float64
+		wantSaved  bool
+		wantErr    bool
+	}{
+		{name: "located restaurant", restaurant: located, want: map[int32]float64{1: 1200, 2: 300}},
+		{
+			name:       "restaurant is geocoded once",
+			restaurant: unlocated,
+			geocoder:   fakeGeocoder{point: geo.Point{Lat: 55.75, Lng: 37.61}},
+			want:       map[int32]float64{1: 1200, 2: 300},
+			wantSaved:  true,
+		},
+		{
+			name:       "address not found leaves distances unknown",
+			restaurant: unlocated,
+			geocoder:   fakeGeocoder{err: geocode.ErrNotFound},
+			want:       map[int32]float64{},
+		},
+		{name: "geocoder failure", restaurant: unlocated, geocoder: fakeGeocoder{err: errors.New("timeout")}, wantErr: true},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			store := &fakeLocatorStore{restaurant: testcase.restaurant, distances: distances}
+			got, err := NewLocator(store, testcase.geocoder).Distances(context.Background(), 7, []int32{1, 2, 3})
+			if (err != nil) != testcase.wantErr {
+				t.Fatalf("got error %v, want error %v", err, testcase.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, testcase.want) {
+				t.Fatalf("got %v, want %v", got, testcase.want)
+			}
+			if (store.saved != nil) != testcase.wantSaved {
+				t.Fatalf("got saved location %v, want saved %v", store.saved, testcase.wantSaved)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+const (
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastLoaded = "least_loaded"
+	StrategyNearest     = "nearest"
+)
+
+var (
+	ErrUnknownStrategy = errors.New("unknown dispatch strategy")
+	ErrNoLocator       = errors.New("nearest strategy needs courier locations")
+)
+
+// Courier is an online courier without an active order or open offer.
+type Courier struct {
+	ID int32
+	// OrderCount is the number of orders the courier has delivered, from CouriersStats.
+	OrderCount int32
+}
+
+// Strategy puts the candidates in the order they should get the offer, the first one gets it.
+type Strategy interface {
+	Rank(ctx context.Context, orderID int32, couriers []Courier) ([]Courier, error)
+}
+
+// Locator reports the distance in meters from couriers to the restaurant of the order.
+// Couriers with unknown position are left out of the result.
+type Locator interface {
+	Distances(ctx context.Context, orderID int32, courierIDs []int32) (map[int32]float64, error)
+}
+
+// NewStrategy builds a strategy by its config name. locator is needed only by the nearest strategy.
+func NewStrategy(name string, locator Locator) (Strategy, error) {
+	switch name {
+	case StrategyRoundRobin:
+		return &RoundRobin{}, nil
+	case StrategyLeastLoaded:
+		return LeastLoaded{}, nil
+	case StrategyNearest:
+		if locator == nil {
+			return nil, ErrNoLocator
+		}
+		return NewNearest(locator), nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, name)
+}
+
+// RoundRobin goes through couriers by ID, starting after the courier who got the previous offer.
+type RoundRobin struct {
+	mu   sync.Mutex
+	last int32
+}
+
+func (s *RoundRobin) Rank(_ context.Context, _ int32, couriers []Courier) ([]Courier, error) {
+	sorted := slices.Clone(couriers)
+	slices.SortFunc(sorted, func(a, b Courier) int {
+		return int(a.ID) - int(b.ID)
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := 0
+	for i, c := range sorted {
+		if c.ID > s.last {
+			start = i
+			break
+		}
+	}
+	ranked := make([]Courier, 0, len(sorted))
+	ranked = append(ranked, sorted[start:]...)
+	ranked = append(ranked, sorted[:start]...)
+	if len(ranked) > 0 {
+		s.last = ranked[0].ID
+	}
+	return ranked, nil
+}
+
+// LeastLoaded prefers couriers with fewer delivered orders, so work is spread evenly over a shift.
+type LeastLoaded struct{}
+
+func (LeastLoaded) Rank(_ context.Context, _ int32, couriers []Courier) ([]Courier, error) {
+	ranked := slices.Clone(couriers)
+	slices.SortStableFunc(ranked, func(a, b Courier) int {
+		if a.OrderCount != b.OrderCount {
+			return int(a.OrderCount) - int(b.OrderCount)
+		}
+		return int(a.ID) - int(b.ID)
+	})
+	return ranked, nil
+}
+
+// Nearest prefers couriers closest to the restaurant, couriers with unknown position go last.
+type Nearest struct {
+	locator Locator
+}
+
+func NewNearest(locator Locator) *Nearest {
+	return &Nearest{locator: locator}
+}
+
+func (s *Nearest) Rank(ctx context.Context, orderID int32, couriers []Courier) ([]Courier, error) {
+	ids := make([]int32, 0, len(couriers))
+	for _, c := range couriers {
+		ids = append(ids, c.ID)
+	}
+	distances, err := s.locator.Distances(ctx, orderID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := slices.Clone(couriers)
+	slices.SortStableFunc(ranked, func(a, b Courier) int {
+		da, okA := distances[a.ID]
+		db, okB := distances[b.ID]
+		switch {
+		case okA && !okB:
+			return -1
+		case !okA && okB:
+			return 1
+		case okA && da != db:
+			if da < db {
+				return -1
+			}
+			return 1
+		}
+		return int(a.ID) - int(b.ID)
+	})
+	return ranked, nil
+}
@@ -0,0 +1,123 @@
+package dispatch
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fakeLocator map[int32]float64
+
+func (l fakeLocator) Distances(_ context.Context, _ int32, courierIDs []int32) (map[int32]float64, error) {
+	distances := make(map[int32]float64)
+	for _, id := range courierIDs {
+		if d, ok := l[id]; ok {
+			distances[id] = d
+		}
+	}
+	return distances, nil
+}
+
+func ids(couriers []Courier) []int32 {
+	result := make([]int32, 0, len(couriers))
+	for _, c := range couriers {
+		result = append(result, c.ID)
+	}
+	return result
+}
+
+func TestLeastLoaded(t *testing.T) {
+	testcases := []struct {
+		name     string
+		couriers []Courier
+		want     []int32
+	}{
+		{name: "no couriers", couriers: nil, want: []int32{}},
+		{
+			name:     "fewest delivered orders first",
+			couriers: []Courier{{ID: 1, OrderCount: 12}, {ID: 2, OrderCount: 3}, {ID: 3, OrderCount: 7}},
+			want:     []int32{2, 3, 1},
+		},
+		{
+			name:     "ties broken by id",
+			couriers: []Courier{{ID: 9, OrderCount: 1}, {ID: 4, OrderCount: 1}, {ID: 6, OrderCount: 0}},
+			want:     []int32{6, 4, 9},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			ranked, err := LeastLoaded{}.Rank(context.Background(), 1, testcase.couriers)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := ids(ranked); !reflect.DeepEqual(got, testcase.want) {
+				t.Errorf("ranked = %v, want %v", got, testcase.want)
+			}
+		})
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	s := &RoundRobin{}
+	couriers := []Courier{{ID: 5}, {ID: 2}, {ID: 8}}
+
+	// every call starts after the courier who got the previous offer and wraps around
+	want := []int32{2, 5, 8, 2}
+	for i, first := range want {
+		ranked, err := s.Rank(context.Background(), 1, couriers)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(ranked) != len(couriers) {
+			t.Fatalf("call %d: got %d couriers, want %d", i, len(ranked), len(couriers))
+		}
+		if ranked[0].ID != first {
+			t.Errorf("call %d: first = %d, want %d", i, ranked[0].ID, first)
+		}
+	}
+
+	// a courier who went offline is skipped
+	ranked, _ := s.Rank(context.Background(), 1, []Courier{{ID: 2}, {ID: 8}})
+	if got := ids(ranked); !reflect.DeepEqual(got, []int32{8, 2}) {
+		t.Errorf("ranked = %v, want [8 2]", got)
+	}
+}
+
+func TestNearest(t *testing.T) {
+	s := NewNearest(fakeLocator{1: 1500, 2: 300, 4: 300})
+	couriers := []Courier{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+
+	ranked, err := s.Rank(context.Background(), 1, couriers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// courier 3 has no known position and goes last
+	if got, want := ids(ranked), []int32{2, 4, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ranked = %v, want %v", got, want)
+	}
+}
+
+func TestNewStrategy(t *testing.T) {
+	testcases := []struct {
+		name    string
+		locator Locator
+		wantErr error
+	}{
+		{name: StrategyRoundRobin},
+		{name: StrategyLeastLoaded},
+		{name: StrategyNearest, locator: fakeLocator{}},
+		{name: StrategyNearest, wantErr: ErrNoLocator},
+		{name: "random", wantErr: ErrUnknownStrategy},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			_, err := NewStrategy(testcase.name, testcase.locator)
+			if !errors.Is(err, testcase.wantErr) {
+				t.Errorf("err = %v, want %v", err, testcase.wantErr)
+			}
+		})
+	}
+}
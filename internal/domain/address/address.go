@@ -0,0 +1,83 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxFieldLength limits every part of an address.
+const maxFieldLength = 200
+
+var (
+	ErrStreetRequired   = errors.New("street is required")
+	ErrBuildingRequired = errors.New("building is required")
+	ErrFieldTooLong     = fmt.Errorf("address fields must be at most %d characters", maxFieldLength)
+)
+
+// Address is a delivery address split into the parts a courier needs at the door.
+type Address struct {
+	Street    string
+	Building  string
+	Apartment string
+	Entrance  string
+	Floor     string
+	Intercom  string
+	Comment   string
+}
+
+// Normalize trims spaces around every part.
+func (a Address) Normalize() Address {
+	return Address{
+		Street:    strings.TrimSpace(a.Street),
+		Building:  strings.TrimSpace(a.Building),
+		Apartment: strings.TrimSpace(a.Apartment),
+		Entrance:  strings.TrimSpace(a.Entrance),
+		Floor:     strings.TrimSpace(a.Floor),
+		Intercom:  strings.TrimSpace(a.Intercom),
+		Comment:   strings.TrimSpace(a.Comment),
+	}
+}
+
+func (a Address) Validate() error {
+	if a.Street == "" {
+		return ErrStreetRequired
+	}
+	if a.Building == "" {
+		return ErrBuildingRequired
+	}
+	for _, part := range []string{a.Street, a.Building, a.Apartment, a.Entrance, a.Floor, a.Intercom, a.Comment} {
+		if utf8.RuneCountInString(part) > maxFieldLength {
+			return ErrFieldTooLong
+		}
+	}
+	return nil
+}
+
+// Query is the part of the address a geocoder can find, the rest only matters at the door.
+func (a Address) Query() string {
+	return a.Street + ", " + a.Building
+}
+
+// String formats the address in one line, the way it is stored on orders and shown to couriers.
+func (a Address) String() string {
+	parts := []string{a.Street, a.Building}
+	if a.Apartment != "" {
+		parts = append(parts, "apt "+a.Apartment)
+	}
+	if a.Entrance != "" {
+		parts = append(parts, "entrance "+a.Entrance)
+	}
+	if a.Floor != "" {
+		parts = append(parts, "floor "+a.Floor)
+	}
+	if a.Intercom != "" {
+		parts = append(parts, "intercom "+a.Intercom)
+	}
+	s := strings.Join(parts, ", ")
+	if a.Comment != "" {
+		s += " (" + a.Comment + ")"
+	}
+	return s
+}
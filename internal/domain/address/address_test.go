@@ -0,0 +1,65 @@
+package address
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	testcases := []struct {
+		name    string
+		address Address
+		wantErr error
+	}{
+		{name: "street and building", address: Address{Street: "Tverskaya", Building: "7"}},
+		{name: "no street", address: Address{Building: "7"}, wantErr: ErrStreetRequired},
+		{name: "no building", address: Address{Street: "Tverskaya"}, wantErr: ErrBuildingRequired},
+		{
+			name:    "too long comment",
+			address: Address{Street: "Tverskaya", Building: "7", Comment: strings.Repeat("a", maxFieldLength+1)},
+			wantErr: ErrFieldTooLong,
+		},
+		{
+			name:    "length counts letters not bytes",
+			address: Address{Street: strings.Repeat("я", maxFieldLength), Building: "7"},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if err := testcase.address.Validate(); !errors.Is(err, testcase.wantErr) {
+				t.Fatalf("got %v, want %v", err, testcase.wantErr)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	testcases := []struct {
+		name    string
+		address Address
+		want    string
+	}{
+		{name: "only required", address: Address{Street: "Tverskaya", Building: "7"}, want: "Tverskaya, 7"},
+		{
+			name: "everything",
+			address: Address{Street: "Tverskaya", Building: "7", Apartment: "12", Entrance: "2", Floor: "5",
+				Intercom: "12K", Comment: "call on arrival"},
+			want: "Tverskaya, 7, apt 12, entrance 2, floor 5, intercom 12K (call on arrival)",
+		},
+		{
+			name:    "skips empty parts",
+			address: Address{Street: "Tverskaya", Building: "7", Floor: "5"},
+			want:    "Tverskaya, 7, floor 5",
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if got := testcase.address.String(); got != testcase.want {
+				t.Fatalf("got %q, want %q", got, testcase.want)
+			}
+		})
+	}
+}
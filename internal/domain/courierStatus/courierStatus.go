@@ -0,0 +1,26 @@
+package courierStatus
+
+type Status string
+
+const (
+	Online  Status = "online"
+	Offline Status = "offline"
+	OnBreak Status = "on_break"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case Online, Offline, OnBreak:
+		return true
+	}
+	return false
+}
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// CanTakeOrders reports whether the courier may see pending orders, get offers and take orders.
+func (s Status) CanTakeOrders() bool {
+	return s == Online
+}
@@ -0,0 +1,99 @@
+package deliveryZone
+
+import (
+	"errors"
+	"fmt"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+)
+
+type Kind string
+
+const (
+	Radius  Kind = "radius"
+	Polygon Kind = "polygon"
+)
+
+const (
+	MaxRadiusM       = 100_000
+	MinPolygonPoints = 3
+	MaxPolygonPoints = 100
+)
+
+var (
+	ErrUnknownKind = errors.New("kind must be radius or polygon")
+	ErrRadiusZone  = fmt.Errorf("radius zone needs a center and radius_m from 1 to %d", MaxRadiusM)
+	ErrPolygonZone = fmt.Errorf("polygon zone needs from %d to %d points", MinPolygonPoints, MaxPolygonPoints)
+)
+
+func (k Kind) IsValid() bool {
+	switch k {
+	case Radius, Polygon:
+		return true
+	}
+	return false
+}
+
+func (k Kind) String() string {
+	return string(k)
+}
+
+// Area is the shape of a zone. Center and RadiusM are used by radius zones, Polygon by polygon zones.
+type Area struct {
+	Kind    Kind
+	Center  *geo.Point
+	RadiusM float64
+	Polygon []geo.Point
+}
+
+// Terms are the order conditions of a zone in minor units of the restaurant currency.
+type Terms struct {
+	MinOrder    int64
+	DeliveryFee int64
+}
+
+// Cheapest picks the zone with the lowest fee among those whose minimum the subtotal meets,
+// the earlier zone wins a tie. ok is false when the subtotal meets no minimum.
+func Cheapest(zones []Terms, subtotal int64) (index int, ok bool) {
+	index = -1
+	for i, z := range zones {
+		if subtotal < z.MinOrder {
+			continue
+		}
+		if index == -1 || z.DeliveryFee < zones[index].DeliveryFee {
+			index = i
+		}
+	}
+	return index, index != -1
+}
+
+// LowestMinimum is the smallest subtotal any of the zones accepts.
+func LowestMinimum(zones []Terms) int64 {
+	var lowest int64
+	for i, z := range zones {
+		if i == 0 || z.MinOrder < lowest {
+			lowest = z.MinOrder
+		}
+	}
+	return lowest
+}
+
+func (a Area) Validate() error {
+	switch a.Kind {
+	case Radius:
+		if a.Center == nil || a.RadiusM < 1 || a.RadiusM > MaxRadiusM {
+			return ErrRadiusZone
+		}
+		return a.Center.Validate()
+	case Polygon:
+		if len(a.Polygon) < MinPolygonPoints || len(a.Polygon) > MaxPolygonPoints {
+			return ErrPolygonZone
+		}
+		for _, p := range a.Polygon {
+			if err := p.Validate(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ErrUnknownKind
+}
@@ -0,0 +1,86 @@
+package deliveryZone
+
+import (
+	"errors"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	center := &geo.Point{Lat: 55.7558, Lng: 37.6173}
+	triangle := []geo.Point{{Lat: 55.70, Lng: 37.50}, {Lat: 55.80, Lng: 37.60}, {Lat: 55.70, Lng: 37.70}}
+
+	testcases := []struct {
+		name    string
+		area    Area
+		wantErr error
+	}{
+		{name: "radius", area: Area{Kind: Radius, Center: center, RadiusM: 3000}},
+		{name: "radius without center", area: Area{Kind: Radius, RadiusM: 3000}, wantErr: ErrRadiusZone},
+		{name: "zero radius", area: Area{Kind: Radius, Center: center}, wantErr: ErrRadiusZone},
+		{name: "radius too big", area: Area{Kind: Radius, Center: center, RadiusM: MaxRadiusM + 1}, wantErr: ErrRadiusZone},
+		{
+			name:    "center out of range",
+			area:    Area{Kind: Radius, Center: &geo.Point{Lat: 91}, RadiusM: 3000},
+			wantErr: geo.ErrInvalidPoint,
+		},
+		{name: "polygon", area: Area{Kind: Polygon, Polygon: triangle}},
+		{name: "polygon of two points", area: Area{Kind: Polygon, Polygon: triangle[:2]}, wantErr: ErrPolygonZone},
+		{
+			name:    "polygon point out of range",
+			area:    Area{Kind: Polygon, Polygon: append([]geo.Point{{Lng: 181}}, triangle...)},
+			wantErr: geo.ErrInvalidPoint,
+		},
+		{name: "unknown kind", area: Area{Kind: "square"}, wantErr: ErrUnknownKind},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if err := testcase.area.Validate(); !errors.Is(err, testcase.wantErr) {
+				t.Fatalf("got %v, want %v", err, testcase.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheapest(t *testing.T) {
+	zones := []Terms{
+		{MinOrder: 100000, DeliveryFee: 0},
+		{MinOrder: 50000, DeliveryFee: 9900},
+		{MinOrder: 0, DeliveryFee: 19900},
+		{MinOrder: 0, DeliveryFee: 19900},
+	}
+
+	testcases := []struct {
+		name      string
+		zones     []Terms
+		subtotal  int64
+		wantIndex int
+		wantOK    bool
+	}{
+		{name: "all minimums met", zones: zones, subtotal: 150000, wantIndex: 0, wantOK: true},
+		{name: "cheapest minimum not met", zones: zones, subtotal: 60000, wantIndex: 1, wantOK: true},
+		{name: "exactly the minimum", zones: zones, subtotal: 50000, wantIndex: 1, wantOK: true},
+		{name: "tie goes to the earlier zone", zones: zones, subtotal: 100, wantIndex: 2, wantOK: true},
+		{name: "no minimum met", zones: zones[:2], subtotal: 100, wantIndex: -1},
+		{name: "no zones", subtotal: 100, wantIndex: -1},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			index, ok := Cheapest(testcase.zones, testcase.subtotal)
+			if index != testcase.wantIndex || ok != testcase.wantOK {
+				t.Fatalf("got %d %v, want %d %v", index, ok, testcase.wantIndex, testcase.wantOK)
+			}
+		})
+	}
+}
+
+func TestLowestMinimum(t *testing.T) {
+	if got := LowestMinimum([]Terms{{MinOrder: 50000}, {MinOrder: 30000}, {MinOrder: 70000}}); got != 30000 {
+		t.Fatalf("got %d, want 30000", got)
+	}
+	if got := LowestMinimum(nil); got != 0 {
+		t.Fatalf("got %d, want 0", got)
+	}
+}
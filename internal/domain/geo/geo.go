@@ -0,0 +1,18 @@
+package geo
+
+import "errors"
+
+var ErrInvalidPoint = errors.New("latitude must be within -90..90 and longitude within -180..180")
+
+// Point is a WGS 84 coordinate in degrees.
+type Point struct {
+	Lat float64 `json:"lat" example:"55.7558"`
+	Lng float64 `json:"lng" example:"37.6173"`
+}
+
+func (p Point) Validate() error {
+	if p.Lat < -90 || p.Lat > 90 || p.Lng < -180 || p.Lng > 180 {
+		return ErrInvalidPoint
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package menuOptions
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	KindSingle = "single"
+	KindMulti  = "multi"
+)
+
+var (
+	ErrInvalidGroup      = errors.New("invalid option group")
+	ErrUnknownOption     = errors.New("unknown option")
+	ErrUnavailableOption = errors.New("option is not available")
+	ErrDuplicateOption   = errors.New("option selected twice")
+	ErrInvalidSelection  = errors.New("invalid option selection")
+)
+
+// Group is a set of options attached to a menu item, e.g. "Size" or "Extras".
+// A single-select group allows at most one option, a required group at least one.
+type Group struct {
+	ID       int32
+	Name     string
+	Kind     string
+	Min      int32
+	Max      int32
+	Required bool
+	Options  []Option
+}
+
+// Option is a choice inside a group. PriceDelta is added to the item price in minor units and may be negative.
+type Option struct {
+	ID         int32
+	Name       string
+	PriceDelta int64
+	Available  bool
+}
+
+// MinSelect is the number of options the customer has to pick, a required group needs at least one.
+func (g Group) MinSelect() int32 {
+	if g.Required && g.Min < 1 {
+		return 1
+	}
+	return g.Min
+}
+
+// Validate checks that the group limits are consistent and can be satisfied by its options.
+func (g Group) Validate() error {
+	switch {
+	case g.Kind != KindSingle && g.Kind != KindMulti:
+		return fmt.Errorf("%w %q: unknown kind %q", ErrInvalidGroup, g.Name, g.Kind)
+	case len(g.Options) == 0:
+		return fmt.Errorf("%w %q: no options", ErrInvalidGroup, g.Name)
+	case g.Min < 0 || g.Max < 1:
+		return fmt.Errorf("%w %q: min must be at least 0 and max at least 1", ErrInvalidGroup, g.Name)
+	case g.Kind == KindSingle && g.Max != 1:
+		return fmt.Errorf("%w %q: single-select group allows exactly one option", ErrInvalidGroup, g.Name)
+	case g.MinSelect() > g.Max:
+		return fmt.Errorf("%w %q: min is greater than max", ErrInvalidGroup, g.Name)
+	case int(g.MinSelect()) > len(g.Options):
+		return fmt.Errorf("%w %q: min is greater than the number of options", ErrInvalidGroup, g.Name)
+	}
+	return nil
+}
+
+// Select checks the chosen option ids against the groups of one menu item
+// and returns the price delta of the selection per item.
+func Select(groups []Group, selected []int32) (int64, error) {
+	type ref struct {
+		group  int
+		option Option
+	}
+	byID := make(map[int32]ref)
+	for gi, g := range groups {
+		for _, o := range g.Options {
+			byID[o.ID] = ref{group: gi, option: o}
+		}
+	}
+
+	counts := make([]int32, len(groups))
+	seen := make(map[int32]bool, len(selected))
+	var delta int64
+	for _, id := range selected {
+		r, ok := byID[id]
+		if !ok {
+			return 0, fmt.Errorf("%w %d", ErrUnknownOption, id)
+		}
+		if !r.option.Available {
+			return 0, fmt.Errorf("%w: %q", ErrUnavailableOption, r.option.Name)
+		}
+		if seen[id] {
+			return 0, fmt.Errorf("%w: %q", ErrDuplicateOption, r.option.Name)
+		}
+		seen[id] = true
+		counts[r.group]++
+		delta += r.option.PriceDelta
+	}
+
+	for gi, g := range groups {
+		if counts[gi] < g.MinSelect() {
+			return 0, fmt.Errorf("%w: choose at least %d in %q", ErrInvalidSelection, g.MinSelect(), g.Name)
+		}
+		if counts[gi] > g.Max {
+			return 0, fmt.Errorf("%w: choose at most %d in %q", ErrInvalidSelection, g.Max, g.Name)
+		}
+	}
+	return delta, nil
+}
+
+// CheapestDelta is the lowest price delta a valid selection over the groups can add to the item:
+// the cheapest options each group forces, plus every other discount its max still allows.
+// Availability is ignored, an option switched off today may be back tomorrow.
+func CheapestDelta(groups []Group) int64 {
+	var total int64
+	for _, g := range groups {
+		deltas := make([]int64, 0, len(g.Options))
+		for _, o := range g.Options {
+			deltas = append(deltas, o.PriceDelta)
+		}
+		slices.Sort(deltas)
+		for i, d := range deltas {
+			if int32(i) >= g.Max || (int32(i) >= g.MinSelect() && d >= 0) {
+				break
+			}
+			total += d
+		}
+	}
+	return total
+}
@@ -0,0 +1,131 @@
+package menuOptions
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	options := []Option{{ID: 1, Name: "S"}, {ID: 2, Name: "L"}}
+
+	testcases := []struct {
+		name    string
+		group   Group
+		wantErr error
+	}{
+		{name: "single", group: Group{Name: "Size", Kind: KindSingle, Max: 1, Required: true, Options: options}},
+		{name: "multi", group: Group{Name: "Extras", Kind: KindMulti, Min: 0, Max: 2, Options: options}},
+		{name: "unknown kind", group: Group{Name: "Size", Kind: "any", Max: 1, Options: options}, wantErr: ErrInvalidGroup},
+		{name: "no options", group: Group{Name: "Size", Kind: KindSingle, Max: 1}, wantErr: ErrInvalidGroup},
+		{name: "negative min", group: Group{Name: "Extras", Kind: KindMulti, Min: -1, Max: 2, Options: options}, wantErr: ErrInvalidGroup},
+		{name: "zero max", group: Group{Name: "Extras", Kind: KindMulti, Max: 0, Options: options}, wantErr: ErrInvalidGroup},
+		{name: "single with max 2", group: Group{Name: "Size", Kind: KindSingle, Max: 2, Options: options}, wantErr: ErrInvalidGroup},
+		{name: "min above max", group: Group{Name: "Extras", Kind: KindMulti, Min: 2, Max: 1, Options: options}, wantErr: ErrInvalidGroup},
+		{name: "min above options", group: Group{Name: "Extras", Kind: KindMulti, Min: 3, Max: 3, Options: options}, wantErr: ErrInvalidGroup},
+		{
+			name:  "required counts as min 1",
+			group: Group{Name: "Sauce", Kind: KindMulti, Required: true, Max: 1, Options: options[:1]},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if err := testcase.group.Validate(); !errors.Is(err, testcase.wantErr) {
+				t.Fatalf("got %v, want %v", err, testcase.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	size := Group{ID: 1, Name: "Size", Kind: KindSingle, Max: 1, Required: true, Options: []Option{
+		{ID: 1, Name: "M", Available: true},
+		{ID: 2, Name: "L", PriceDelta: 5000, Available: true},
+		{ID: 3, Name: "XL", PriceDelta: 9000},
+	}}
+	extras := Group{ID: 2, Name: "Extras", Kind: KindMulti, Max: 2, Options: []Option{
+		{ID: 4, Name: "cheese", PriceDelta: 3000, Available: true},
+		{ID: 5, Name: "bacon", PriceDelta: 4000, Available: true},
+		{ID: 6, Name: "no onions", PriceDelta: -500, Available: true},
+	}}
+	groups := []Group{size, extras}
+
+	testcases := []struct {
+		name      string
+		groups    []Group
+		selected  []int32
+		wantDelta int64
+		wantErr   error
+	}{
+		{name: "no groups no options", wantDelta: 0},
+		{name: "required single", groups: groups, selected: []int32{2}, wantDelta: 5000},
+		{name: "single and multi", groups: groups, selected: []int32{2, 4, 5}, wantDelta: 12000},
+		{name: "negative delta lowers the price", groups: groups, selected: []int32{1, 6}, wantDelta: -500},
+		{name: "required group skipped", groups: groups, selected: []int32{4}, wantErr: ErrInvalidSelection},
+		{name: "two in single group", groups: groups, selected: []int32{1, 2}, wantErr: ErrInvalidSelection},
+		{name: "above multi max", groups: groups, selected: []int32{1, 4, 5, 6}, wantErr: ErrInvalidSelection},
+		{name: "unknown option", groups: groups, selected: []int32{1, 42}, wantErr: ErrUnknownOption},
+		{name: "option of another item", groups: []Group{extras}, selected: []int32{1}, wantErr: ErrUnknownOption},
+		{name: "unavailable option", groups: groups, selected: []int32{3}, wantErr: ErrUnavailableOption},
+		{name: "duplicate option", groups: groups, selected: []int32{1, 4, 4}, wantErr: ErrDuplicateOption},
+		{
+			name: "min of multi group",
+			groups: []Group{{ID: 3, Name: "Sauces", Kind: KindMulti, Min: 2, Max: 3, Options: []Option{
+				{ID: 7, Name: "ketchup", Available: true},
+				{ID: 8, Name: "mustard", Available: true},
+			}}},
+			selected: []int32{7},
+			wantErr:  ErrInvalidSelection,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			delta, err := Select(testcase.groups, testcase.selected)
+			if !errors.Is(err, testcase.wantErr) {
+				t.Fatalf("got error %v, want %v", err, testcase.wantErr)
+			}
+			if delta != testcase.wantDelta {
+				t.Fatalf("got delta %d, want %d", delta, testcase.wantDelta)
+			}
+		})
+	}
+}
+
+func TestCheapestDelta(t *testing.T) {
+	discounts := []Option{{Name: "no onions", PriceDelta: -500}, {Name: "no sauce", PriceDelta: -300}, {Name: "cheese", PriceDelta: 3000}}
+
+	testcases := []struct {
+		name   string
+		groups []Group
+		want   int64
+	}{
+		{name: "no groups", want: 0},
+		{name: "max limits discounts", groups: []Group{{Kind: KindMulti, Max: 1, Options: discounts}}, want: -500},
+		{name: "all discounts within max", groups: []Group{{Kind: KindMulti, Max: 3, Options: discounts}}, want: -800},
+		{
+			name: "required group forces its cheapest option",
+			groups: []Group{{Kind: KindSingle, Max: 1, Required: true, Options: []Option{
+				{Name: "L", PriceDelta: 5000},
+				{Name: "M", PriceDelta: 2000},
+			}}},
+			want: 2000,
+		},
+		{
+			name: "groups add up",
+			groups: []Group{
+				{Kind: KindSingle, Max: 1, Options: []Option{{Name: "S", PriceDelta: -1000}, {Name: "L", PriceDelta: 1000}}},
+				{Kind: KindMulti, Max: 3, Options: discounts},
+			},
+			want: -1800,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if got := CheapestDelta(testcase.groups); got != testcase.want {
+				t.Fatalf("got %d, want %d", got, testcase.want)
+			}
+		})
+	}
+}
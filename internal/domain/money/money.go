@@ -0,0 +1,48 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+)
+
+const DefaultCurrency = "RUB"
+
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Money is an amount in minor units (kopecks, cents) of the given currency,
+// so prices and totals are summed without float rounding.
+type Money struct {
+	Amount   int64  `json:"amount" example:"30000"`
+	Currency string `json:"currency" example:"RUB"`
+}
+
+func New(amount int64, currency string) Money {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return Money{Amount: amount, Currency: currency}
+}
+
+func (m Money) Mul(n int64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Percent returns p percent of m rounded half up to the nearest minor unit.
+func (m Money) Percent(p int64) Money {
+	return Money{Amount: (m.Amount*p + 50) / 100, Currency: m.Currency}
+}
+
+func (m Money) String() string {
+	sign, amount := "", m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, amount/100, amount%100, m.Currency)
+}
@@ -0,0 +1,53 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	testcases := []struct {
+		name  string
+		money Money
+		want  string
+	}{
+		{name: "whole", money: New(30000, "RUB"), want: "300.00 RUB"},
+		{name: "minor units", money: New(12305, "RUB"), want: "123.05 RUB"},
+		{name: "zero", money: New(0, "USD"), want: "0.00 USD"},
+		{name: "negative", money: New(-12345, "RUB"), want: "-123.45 RUB"},
+		{name: "negative below one", money: New(-5, "RUB"), want: "-0.05 RUB"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if got := testcase.money.String(); got != testcase.want {
+				t.Fatalf("got %q, want %q", got, testcase.want)
+			}
+		})
+	}
+}
+
+func TestAdd(t *testing.T) {
+	testcases := []struct {
+		name    string
+		a, b    Money
+		want    Money
+		wantErr error
+	}{
+		{name: "same currency", a: New(100, "RUB"), b: New(250, "RUB"), want: New(350, "RUB")},
+		{name: "default currency", a: New(100, ""), b: New(1, "RUB"), want: New(101, "RUB")},
+		{name: "currency mismatch", a: New(100, "RUB"), b: New(100, "USD"), wantErr: ErrCurrencyMismatch},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := testcase.a.Add(testcase.b)
+			if !errors.Is(err, testcase.wantErr) {
+				t.Fatalf("got error %v, want %v", err, testcase.wantErr)
+			}
+			if got != testcase.want {
+				t.Fatalf("got %v, want %v", got, testcase.want)
+			}
+		})
+	}
+}
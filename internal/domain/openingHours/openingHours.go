@@ -0,0 +1,114 @@
+package openingHours
+
+import (
+	"sort"
+	"time"
+)
+
+// searchDays bounds the lookup of the next opening, long holidays included.
+const searchDays = 60
+
+// Interval is a weekly opening window. Closes at or before Opens means the window runs past midnight.
+// Opens and Closes are offsets from local midnight.
+type Interval struct {
+	Weekday time.Weekday
+	Opens   time.Duration
+	Closes  time.Duration
+}
+
+// Exception overrides the weekly hours on one local date: closed all day or open in a single window.
+type Exception struct {
+	Year   int
+	Month  time.Month
+	Day    int
+	Closed bool
+	Opens  time.Duration
+	Closes time.Duration
+}
+
+// Schedule is a restaurant's opening hours in its own time zone.
+// An empty weekly schedule means the restaurant never set hours and is treated as always open.
+type Schedule struct {
+	Location   *time.Location
+	Weekly     []Interval
+	Exceptions []Exception
+}
+
+type window struct {
+	start, end time.Time
+}
+
+func (s Schedule) location() *time.Location {
+	if s.Location == nil {
+		return time.UTC
+	}
+	return s.Location
+}
+
+// IsOpen reports whether t falls into an opening window.
+func (s Schedule) IsOpen(t time.Time) bool {
+	t = t.In(s.location())
+	// windows of the previous day may run past midnight
+	for _, day := range []time.Time{t.AddDate(0, 0, -1), t} {
+		for _, w := range s.windows(day) {
+			if !t.Before(w.start) && t.Before(w.end) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NextOpening returns the start of the first opening window after t.
+// ok is false if the restaurant does not open within the search horizon.
+func (s Schedule) NextOpening(t time.Time) (time.Time, bool) {
+	t = t.In(s.location())
+	for i := 0; i < searchDays; i++ {
+		for _, w := range s.windows(t.AddDate(0, 0, i)) {
+			if w.start.After(t) {
+				return w.start, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// windows lists the opening windows starting on the local date of day, sorted by start.
+func (s Schedule) windows(day time.Time) []window {
+	y, m, d := day.Date()
+	for _, e := range s.Exceptions {
+		if e.Year == y && e.Month == m && e.Day == d {
+			if e.Closed {
+				return nil
+			}
+			return []window{s.window(day, e.Opens, e.Closes)}
+		}
+	}
+
+	if len(s.Weekly) == 0 {
+		// midnight to midnight
+		return []window{s.window(day, 0, 0)}
+	}
+
+	var res []window
+	for _, interval := range s.Weekly {
+		if interval.Weekday == day.Weekday() {
+			res = append(res, s.window(day, interval.Opens, interval.Closes))
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].start.Before(res[j].start) })
+	return res
+}
+
+func (s Schedule) window(day time.Time, opens, closes time.Duration) window {
+	y, m, d := day.Date()
+	// time.Date instead of Add keeps wall-clock hours right across DST switches
+	w := window{
+		start: time.Date(y, m, d, 0, 0, int(opens/time.Second), 0, day.Location()),
+		end:   time.Date(y, m, d, 0, 0, int(closes/time.Second), 0, day.Location()),
+	}
+	if closes <= opens {
+		w.end = time.Date(y, m, d+1, 0, 0, int(closes/time.Second), 0, day.Location())
+	}
+	return w
+}
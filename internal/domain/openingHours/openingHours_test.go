@@ -0,0 +1,169 @@
+package openingHours
+
+import (
+	"testing"
+	"time"
+)
+
+var moscow = time.FixedZone("MSK", 3*60*60)
+
+// 2025-06-16 is a Monday.
+func at(day, hour, minute int) time.Time {
+	return time.Date(2025, time.June, day, hour, minute, 0, 0, moscow)
+}
+
+func TestIsOpen(t *testing.T) {
+	weekdays := []Interval{
+		{Weekday: time.Monday, Opens: 9 * time.Hour, Closes: 18 * time.Hour},
+		{Weekday: time.Tuesday, Opens: 9 * time.Hour, Closes: 18 * time.Hour},
+	}
+	overnight := []Interval{{Weekday: time.Friday, Opens: 22 * time.Hour, Closes: 2 * time.Hour}}
+
+	testcases := []struct {
+		name     string
+		schedule Schedule
+		t        time.Time
+		want     bool
+	}{
+		{name: "no hours is always open", schedule: Schedule{Location: moscow}, t: at(16, 3, 0), want: true},
+		{name: "inside window", schedule: Schedule{Location: moscow, Weekly: weekdays}, t: at(16, 10, 0), want: true},
+		{name: "opening minute", schedule: Schedule{Location: moscow, Weekly: weekdays}, t: at(16, 9, 0), want: true},
+		{name: "closing minute is closed", schedule: Schedule{Location: moscow, Weekly: weekdays}, t: at(16, 18, 0)},
+		{name: "before opening", schedule: Schedule{Location: moscow, Weekly: weekdays}, t: at(16, 8, 59)},
+		{name: "day without hours", schedule: Schedule{Location: moscow, Weekly: weekdays}, t: at(18, 10, 0)},
+		{
+			name:     "time in another zone",
+			schedule: Schedule{Location: moscow, Weekly: weekdays},
+			t:        time.Date(2025, time.June, 16, 6, 30, 0, 0, time.UTC),
+			want:     true,
+		},
+		{name: "overnight before midnight", schedule: Schedule{Location: moscow, Weekly: overnight}, t: at(20, 23, 0), want: true},
+		{name: "overnight after midnight", schedule: Schedule{Location: moscow, Weekly: overnight}, t: at(21, 1, 30), want: true},
+		{name: "overnight closing", schedule: Schedule{Location: moscow, Weekly: overnight}, t: at(21, 2, 0)},
+		{
+			name: "holiday closes a working day",
+			schedule: Schedule{Location: moscow, Weekly: weekdays, Exceptions: []Exception{
+				{Year: 2025, Month: time.June, Day: 16, Closed: true},
+			}},
+			t: at(16, 10, 0),
+		},
+		{
+			name: "holiday hours replace weekly hours",
+			schedule: Schedule{Location: moscow, Weekly: weekdays, Exceptions: []Exception{
+				{Year: 2025, Month: time.June, Day: 16, Opens: 12 * time.Hour, Closes: 14 * time.Hour},
+			}},
+			t: at(16, 10, 0),
+		},
+		{
+			name: "holiday opens a day off",
+			schedule: Schedule{Location: moscow, Weekly: weekdays, Exceptions: []Exception{
+				{Year: 2025, Month: time.June, Day: 22, Opens: 12 * time.Hour, Closes: 14 * time.Hour},
+			}},
+			t:    at(22, 13, 0),
+			want: true,
+		},
+		{
+			name: "holiday ends the night of the day before",
+			schedule: Schedule{Location: moscow, Weekly: overnight, Exceptions: []Exception{
+				{Year: 2025, Month: time.June, Day: 20, Closed: true},
+			}},
+			t: at(21, 1, 0),
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if got := testcase.schedule.IsOpen(testcase.t); got != testcase.want {
+				t.Fatalf("got %v, want %v", got, testcase.want)
+			}
+		})
+	}
+}
+
+func TestIsOpenAcrossDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	// 2025-03-30 is a Sunday, clocks jump from 02:00 to 03:00
+	schedule := Schedule{Location: berlin, Weekly: []Interval{
+		{Weekday: time.Sunday, Opens: 9 * time.Hour, Closes: 18 * time.Hour},
+	}}
+
+	testcases := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{name: "09:30 summer time", t: time.Date(2025, time.March, 30, 7, 30, 0, 0, time.UTC), want: true},
+		{name: "08:30 summer time", t: time.Date(2025, time.March, 30, 6, 30, 0, 0, time.UTC)},
+		{name: "17:30 summer time", t: time.Date(2025, time.March, 30, 15, 30, 0, 0, time.UTC), want: true},
+		{name: "18:30 summer time", t: time.Date(2025, time.March, 30, 16, 30, 0, 0, time.UTC)},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			if got := schedule.IsOpen(testcase.t); got != testcase.want {
+				t.Fatalf("got %v, want %v", got, testcase.want)
+			}
+		})
+	}
+}
+
+func TestNextOpening(t *testing.T) {
+	weekdays := []Interval{
+		{Weekday: time.Monday, Opens: 9 * time.Hour, Closes: 18 * time.Hour},
+		{Weekday: time.Tuesday, Opens: 9 * time.Hour, Closes: 18 * time.Hour},
+		{Weekday: time.Tuesday, Opens: 20 * time.Hour, Closes: 23 * time.Hour},
+	}
+
+	testcases := []struct {
+		name     string
+		schedule Schedule
+		t        time.Time
+		want     time.Time
+		wantOK   bool
+	}{
+		{name: "later today", schedule: Schedule{Location: moscow, Weekly: weekdays}, t: at(16, 7, 0), want: at(16, 9, 0), wantOK: true},
+		{name: "next day", schedule: Schedule{Location: moscow, Weekly: weekdays}, t: at(16, 19, 0), want: at(17, 9, 0), wantOK: true},
+		{name: "second window of the day", schedule: Schedule{Location: moscow, Weekly: weekdays}, t: at(17, 18, 30), want: at(17, 20, 0), wantOK: true},
+		{name: "next week", schedule: Schedule{Location: moscow, Weekly: weekdays}, t: at(17, 23, 30), want: at(23, 9, 0), wantOK: true},
+		{
+			name: "skips a holiday",
+			schedule: Schedule{Location: moscow, Weekly: weekdays, Exceptions: []Exception{
+				{Year: 2025, Month: time.June, Day: 17, Closed: true},
+			}},
+			t:      at(16, 19, 0),
+			want:   at(23, 9, 0),
+			wantOK: true,
+		},
+		{
+			name:     "never opens within the horizon",
+			schedule: Schedule{Location: moscow, Weekly: weekdays[:1], Exceptions: closedMondays(2025, time.June, 16, 10)},
+			t:        at(16, 7, 0),
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, ok := testcase.schedule.NextOpening(testcase.t)
+			if ok != testcase.wantOK {
+				t.Fatalf("got ok %v, want %v", ok, testcase.wantOK)
+			}
+			if ok && !got.Equal(testcase.want) {
+				t.Fatalf("got %v, want %v", got, testcase.want)
+			}
+		})
+	}
+}
+
+// closedMondays closes n Mondays in a row starting at the given date.
+func closedMondays(year int, month time.Month, day, n int) []Exception {
+	res := make([]Exception, 0, n)
+	start := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		y, m, d := start.AddDate(0, 0, 7*i).Date()
+		res = append(res, Exception{Year: y, Month: m, Day: d, Closed: true})
+	}
+	return res
+}
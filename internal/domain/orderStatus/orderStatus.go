@@ -0,0 +1,70 @@
+package orderStatus
+
+import "slices"
+
+type Status string
+
+const (
+	Pending        Status = "pending"
+	Accepted       Status = "accepted"
+	Preparing      Status = "preparing"
+	ReadyForPickup Status = "ready_for_pickup"
+	Delivering     Status = "delivering"
+	Delivered      Status = "delivered"
+	Cancelled      Status = "cancelled"
+	Rejected       Status = "rejected"
+)
+
+// transitions lists every status an order may move to from the given one.
+var transitions = map[Status][]Status{
+	Pending:        {Accepted, Rejected, Cancelled},
+	Accepted:       {Preparing, ReadyForPickup, Cancelled},
+	Preparing:      {ReadyForPickup, Cancelled},
+	ReadyForPickup: {Delivering, Cancelled},
+	Delivering:     {Delivered},
+	Delivered:      {},
+	Cancelled:      {},
+	Rejected:       {},
+}
+
+// assignable are the statuses in which a courier can take the order.
+var assignable = []Status{Accepted, Preparing, ReadyForPickup}
+
+func (s Status) IsValid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+func (s Status) IsFinal() bool {
+	return s.IsValid() && len(transitions[s]) == 0
+}
+
+func (s Status) String() string {
+	return string(s)
+}
+
+func CanTransition(from, to Status) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+func CanAssignCourier(s Status) bool {
+	return slices.Contains(assignable, s)
+}
+
+// CustomerCanCancel reports whether the customer may still cancel the order:
+// once the restaurant has accepted it, only the restaurant can stop it.
+func CustomerCanCancel(s Status) bool {
+	return s == Pending
+}
+
+// RestaurantCanCancel reports whether the restaurant may cancel an order it has already accepted,
+// up to the moment the courier picks it up. A new order is rejected instead.
+func RestaurantCanCancel(s Status) bool {
+	return s != Pending && CanTransition(s, Cancelled)
+}
+
+// CanDropCourier reports whether the assigned courier may give the order back to the pool,
+// which is possible only until the order is picked up.
+func CanDropCourier(s Status) bool {
+	return CanAssignCourier(s)
+}
@@ -0,0 +1,145 @@
+package events
+
+import (
+	"context"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Event types.
+const (
+	TypePlaced            = "order_placed"
+	TypeStatusChanged     = "status_changed"
+	TypeCourierAssigned   = "courier_assigned"
+	TypeCourierUnassigned = "courier_unassigned"
+)
+
+// subscriberBuffer is how many events a subscriber may lag behind before it is dropped.
+const subscriberBuffer = 64
+
+type Event struct {
+	ID           int64     `json:"id" example:"42"`
+	OrderID      int32     `json:"order_id" example:"12"`
+	RestaurantID int32     `json:"restaurant_id" example:"3"`
+	Type         string    `json:"type" example:"status_changed"`
+	Status       string    `json:"status" example:"accepted"`
+	CreatedAt    time.Time `json:"created_at" example:"2025-06-17T12:00:00Z"`
+}
+
+// FromRow converts a stored event.
+func FromRow(row database.OrderEvent) Event {
+	return Event{
+		ID:           row.ID,
+		OrderID:      row.OrderID,
+		RestaurantID: row.RestaurantID,
+		Type:         row.Type,
+		Status:       row.Status,
+		CreatedAt:    row.CreatedAt,
+	}
+}
+
+// Filter selects the events of one order or of one restaurant, zero fields match anything.
+type Filter struct {
+	OrderID      int32
+	RestaurantID int32
+}
+
+func (f Filter) match(e Event) bool {
+	return (f.OrderID == 0 || f.OrderID == e.OrderID) &&
+		(f.RestaurantID == 0 || f.RestaurantID == e.RestaurantID)
+}
+
+// Subscription receives matching events on C. C is closed on Unsubscribe or when the subscriber
+// falls too far behind, the client is then expected to reconnect and replay from the log.
+type Subscription struct {
+	C      <-chan Event
+	ch     chan Event
+	filter Filter
+}
+
+type store interface {
+	CreateOrderEvent(ctx context.Context, arg database.CreateOrderEventParams) (database.OrderEvent, error)
+}
+
+// Bus persists order events and fans them out to the subscribers of this process.
+type Bus struct {
+	log   *slog.Logger
+	store store
+
+	// publishMu keeps delivery in the order of event ids, so a resumed stream never misses an event
+	publishMu sync.Mutex
+
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+func New(log *slog.Logger, store store) *Bus {
+	return &Bus{
+		log:   log,
+		store: store,
+		subs:  make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish saves the event and sends it to subscribers. The order change is already committed
+// when handlers publish, so failures are only logged.
+func (b *Bus) Publish(ctx context.Context, orderID int32, eventType, status string) {
+	const op = "events.Publish"
+
+	b.publishMu.Lock()
+	defer b.publishMu.Unlock()
+
+	// the request may be cancelled right after the response, the event must still be saved
+	row, err := b.store.CreateOrderEvent(context.WithoutCancel(ctx), database.CreateOrderEventParams{
+		Type:    eventType,
+		Status:  status,
+		OrderID: orderID,
+	})
+	if err != nil {
+		b.log.Error("failed to save order event", slog.String("op", op),
+			slog.Int("order_id", int(orderID)), sl.Err(err))
+		return
+	}
+	event := FromRow(row)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		if !sub.filter.match(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			b.log.Warn("dropping slow event subscriber", slog.String("op", op))
+			b.remove(sub)
+		}
+	}
+}
+
+func (b *Bus) Subscribe(filter Filter) *Subscription {
+	ch := make(chan Event, subscriberBuffer)
+	sub := &Subscription{C: ch, ch: ch, filter: filter}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+func (b *Bus) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remove(sub)
+}
+
+func (b *Bus) remove(sub *Subscription) {
+	if _, ok := b.subs[sub]; !ok {
+		return
+	}
+	delete(b.subs, sub)
+	close(sub.ch)
+}
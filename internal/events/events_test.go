@@ -0,0 +1,109 @@
+package events
+
+import (
+	"context"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+type fakeStore struct {
+	lastID int64
+	// restaurants maps order id to restaurant id like the insert does in SQL
+	restaurants map[int32]int32
+}
+
+func (s *fakeStore) CreateOrderEvent(_ context.Context, arg database.CreateOrderEventParams) (database.OrderEvent, error) {
+	s.lastID++
+	return database.OrderEvent{
+		ID:           s.lastID,
+		OrderID:      arg.OrderID,
+		RestaurantID: s.restaurants[arg.OrderID],
+		Type:         arg.Type,
+		Status:       arg.Status,
+	}, nil
+}
+
+func newBus() *Bus {
+	store := &fakeStore{restaurants: map[int32]int32{1: 10, 2: 10, 3: 20}}
+	return New(slog.New(slog.NewTextHandler(io.Discard, nil)), store)
+}
+
+func received(sub *Subscription) []int32 {
+	var orders []int32
+	for {
+		select {
+		case e := <-sub.C:
+			orders = append(orders, e.OrderID)
+		default:
+			return orders
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	testcases := []struct {
+		name   string
+		filter Filter
+		want   []int32
+	}{
+		{name: "one order", filter: Filter{OrderID: 2}, want: []int32{2}},
+		{name: "one restaurant", filter: Filter{RestaurantID: 10}, want: []int32{1, 2}},
+		{name: "other restaurant", filter: Filter{RestaurantID: 20}, want: []int32{3}},
+		{name: "order of other restaurant", filter: Filter{OrderID: 3, RestaurantID: 10}, want: nil},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			bus := newBus()
+			sub := bus.Subscribe(testcase.filter)
+			defer bus.Unsubscribe(sub)
+
+			for _, orderID := range []int32{1, 2, 3} {
+				bus.Publish(context.Background(), orderID, TypeStatusChanged, "accepted")
+			}
+
+			got := received(sub)
+			if len(got) != len(testcase.want) {
+				t.Fatalf("got %v, want %v", got, testcase.want)
+			}
+			for i := range got {
+				if got[i] != testcase.want[i] {
+					t.Fatalf("got %v, want %v", got, testcase.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSlowSubscriberDropped(t *testing.T) {
+	bus := newBus()
+	slow := bus.Subscribe(Filter{})
+	fast := bus.Subscribe(Filter{})
+	defer bus.Unsubscribe(fast)
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		bus.Publish(context.Background(), 1, TypeStatusChanged, "accepted")
+		if i < subscriberBuffer {
+			<-fast.C
+		}
+	}
+
+	for i := 0; i < subscriberBuffer; i++ {
+		if _, ok := <-slow.C; !ok {
+			t.Fatalf("channel closed after %d events, want %d buffered", i, subscriberBuffer)
+		}
+	}
+	if _, ok := <-slow.C; ok {
+		t.Fatal("slow subscriber was not dropped")
+	}
+
+	e, ok := <-fast.C
+	if !ok || e.ID != subscriberBuffer+1 {
+		t.Fatalf("fast subscriber got %v %v, want event %d", e.ID, ok, subscriberBuffer+1)
+	}
+
+	// unsubscribing a dropped subscriber must not close the channel twice
+	bus.Unsubscribe(slow)
+}
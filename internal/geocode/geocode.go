@@ -0,0 +1,53 @@
+package geocode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+)
+
+const ProviderStub = "stub"
+
+var (
+	ErrNotFound        = errors.New("address not found")
+	ErrUnknownProvider = errors.New("unknown geocoding provider")
+)
+
+// Geocoder finds the point of an address written in one line.
+type Geocoder interface {
+	Geocode(ctx context.Context, query string) (geo.Point, error)
+}
+
+// approximator is implemented by geocoders whose points are made up rather than found.
+type approximator interface {
+	Approximate() bool
+}
+
+// IsApproximate reports whether points of g can't be trusted for anything that depends on the real location,
+// such as delivery zones.
+func IsApproximate(g Geocoder) bool {
+	a, ok := g.(approximator)
+	return ok && a.Approximate()
+}
+
+// New returns the geocoder by provider name. Only the offline stub is built in,
+// it is configured with the area it scatters addresses over.
+func New(provider string, center geo.Point, radiusKm float64) (Geocoder, error) {
+	switch provider {
+	case ProviderStub:
+		return NewStub(center, radiusKm), nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, provider)
+}
+
+// Locate returns the point the client pinned on the map, or geocodes query when nothing is pinned.
+func Locate(ctx context.Context, g Geocoder, query string, pinned *geo.Point) (geo.Point, error) {
+	if pinned != nil {
+		if err := pinned.Validate(); err != nil {
+			return geo.Point{}, err
+		}
+		return *pinned, nil
+	}
+	return g.Geocode(ctx, query)
+}
@@ -0,0 +1,48 @@
+package geocode
+
+import (
+	"context"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"hash/fnv"
+	"math"
+	"strings"
+)
+
+const kmPerDegree = 111.32
+
+// Stub geocodes without any network: every address lands on a stable pseudo-random point
+// within radiusKm of center. It is meant for development and tests, not for real deliveries.
+type Stub struct {
+	center   geo.Point
+	radiusKm float64
+}
+
+func NewStub(center geo.Point, radiusKm float64) *Stub {
+	return &Stub{center: center, radiusKm: radiusKm}
+}
+
+// Approximate is always true, the stub points have nothing to do with the real address.
+func (s *Stub) Approximate() bool {
+	return true
+}
+
+func (s *Stub) Geocode(_ context.Context, query string) (geo.Point, error) {
+	// the same address written with other case or spacing gets the same point
+	normalized := strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	if normalized == "" {
+		return geo.Point{}, ErrNotFound
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(normalized))
+	sum := h.Sum64()
+
+	angle := float64(uint32(sum)) / math.MaxUint32 * 2 * math.Pi
+	// sqrt spreads points evenly over the disc instead of crowding the center
+	distance := s.radiusKm * math.Sqrt(float64(sum>>32)/math.MaxUint32)
+
+	return geo.Point{
+		Lat: s.center.Lat + distance*math.Cos(angle)/kmPerDegree,
+		Lng: s.center.Lng + distance*math.Sin(angle)/(kmPerDegree*math.Cos(s.center.Lat*math.Pi/180)),
+	}, nil
+}
@@ -0,0 +1,82 @@
+package geocode
+
+import (
+	"context"
+	"errors"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"math"
+	"testing"
+)
+
+var center = geo.Point{Lat: 55.7558, Lng: 37.6173}
+
+// distanceKm is an equirectangular approximation, good enough over a few kilometers.
+func distanceKm(a, b geo.Point) float64 {
+	x := (b.Lng - a.Lng) * math.Cos((a.Lat+b.Lat)/2*math.Pi/180)
+	y := b.Lat - a.Lat
+	return math.Sqrt(x*x+y*y) * kmPerDegree
+}
+
+func TestStub(t *testing.T) {
+	stub := NewStub(center, 10)
+
+	testcases := []struct {
+		name  string
+		query string
+		same  string
+	}{
+		{name: "same address same point", query: "Tverskaya, 7", same: "Tverskaya, 7"},
+		{name: "case and spaces ignored", query: "Tverskaya,  7", same: " tverskaya, 7 "},
+		{name: "other address", query: "Arbat, 10", same: "arbat, 10"},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := stub.Geocode(context.Background(), testcase.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := got.Validate(); err != nil {
+				t.Fatalf("invalid point %v: %v", got, err)
+			}
+			if d := distanceKm(center, got); d > 10.01 {
+				t.Fatalf("point %v is %.2f km away, want within 10", got, d)
+			}
+			same, err := stub.Geocode(context.Background(), testcase.same)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if same != got {
+				t.Fatalf("got %v and %v, want the same point", got, same)
+			}
+		})
+	}
+
+	if _, err := stub.Geocode(context.Background(), "   "); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got %v for empty address, want ErrNotFound", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(ProviderStub, center, 10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := New("yandex", center, 10); !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("got %v, want ErrUnknownProvider", err)
+	}
+}
+
+type exact struct{}
+
+func (exact) Geocode(context.Context, string) (geo.Point, error) {
+	return center, nil
+}
+
+func TestIsApproximate(t *testing.T) {
+	if !IsApproximate(NewStub(center, 10)) {
+		t.Fatal("stub points must be approximate")
+	}
+	if IsApproximate(exact{}) {
+		t.Fatal("a geocoder without Approximate must be exact")
+	}
+}
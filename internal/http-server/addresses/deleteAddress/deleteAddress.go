@@ -0,0 +1,80 @@
+package deleteAddress
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type addressDeleter interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
+}
+
+// Addresses godoc
+// @Summary Удаление адреса
+// @Description Удаляет адрес из адресной книги. Если удален адрес по умолчанию, им становится последний сохраненный. Оформленные заказы сохраняют свой адрес
+// @Tags Addresses
+// @Produce json
+// @Param id path int true "ID адреса"
+// @Success 204 "Адрес удален"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Адрес не найден"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /users/me/addresses/{id} [delete]
+// @Security BearerAuth
+func New(log *slog.Logger, deleter addressDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.addresses.deleteAddress"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		addressID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || addressID < 1 {
+			response.Error(log, w, r, "invalid address ID", "failed to parse address ID", http.StatusBadRequest)
+			return
+		}
+
+		err = deleter.InTx(r.Context(), func(q *database.Queries) error {
+			deleted, err := q.DeleteAddress(r.Context(), database.DeleteAddressParams{
+				ID:     int32(addressID),
+				UserID: user.ID,
+			})
+			if err != nil {
+				return err
+			}
+			if !deleted.IsDefault {
+				return nil
+			}
+			return q.PromoteDefaultAddress(r.Context(), user.ID)
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "address not found", "no address of user", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "failed to delete", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("address deleted", slog.Int("address_id", int(addressID)))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
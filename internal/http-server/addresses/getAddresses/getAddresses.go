@@ -0,0 +1,63 @@
+package getAddresses
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/addressStruct"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+type Response struct {
+	Addresses []addressStruct.Address `json:"addresses"`
+}
+
+type addressesGetter interface {
+	GetAddressesByUserID(ctx context.Context, userID int32) ([]database.Address, error)
+}
+
+// Addresses godoc
+// @Summary Адресная книга пользователя
+// @Description Возвращает сохраненные адреса доставки, адрес по умолчанию первым
+// @Tags Addresses
+// @Produce json
+// @Success 200 {object} getAddresses.Response "Адреса получены"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /users/me/addresses [get]
+// @Security BearerAuth
+func New(log *slog.Logger, getter addressesGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.addresses.getAddresses"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		rows, err := getter.GetAddressesByUserID(r.Context(), user.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		resp := Response{Addresses: make([]addressStruct.Address, 0, len(rows))}
+		for _, row := range rows {
+			resp.Addresses = append(resp.Addresses, addressStruct.FromRow(row))
+		}
+
+		log.Info("addresses sent", slog.Int("count", len(rows)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, resp)
+	}
+}
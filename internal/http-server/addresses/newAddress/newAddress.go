@@ -0,0 +1,136 @@
+package newAddress
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/address"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"github.com/yourgfslove/GodFoodApi/internal/geocode"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/addressStruct"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	Street    string     `json:"street" validate:"required" example:"Tverskaya"`
+	Building  string     `json:"building" validate:"required" example:"7"`
+	Apartment string     `json:"apartment,omitempty" example:"12"`
+	Entrance  string     `json:"entrance,omitempty" example:"2"`
+	Floor     string     `json:"floor,omitempty" example:"5"`
+	Intercom  string     `json:"intercom,omitempty" example:"12K"`
+	Comment   string     `json:"comment,omitempty" example:"call on arrival"`
+	Location  *geo.Point `json:"location,omitempty"`
+	IsDefault bool       `json:"is_default,omitempty" example:"true"`
+}
+
+type addressCreater interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
+}
+
+// Addresses godoc
+// @Summary Добавление адреса в адресную книгу
+// @Description Сохраняет адрес доставки. Если location не передан, координаты определяются геокодером по улице и дому, и если геокодер их только придумывает (провайдер stub), located_exactly будет false. Первый адрес и адрес с is_default становятся адресом по умолчанию
+// @Tags Addresses
+// @Accept json
+// @Produce json
+// @Param request body newAddress.Request true "Адрес"
+// @Success 201 {object} addressStruct.Address "Адрес сохранен"
+// @Failure 400 {object} response.Response "Некорректные данные или адрес не найден"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /users/me/addresses [post]
+// @Security BearerAuth
+func New(log *slog.Logger, creater addressCreater, geocoder geocode.Geocoder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.addresses.newAddress"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		addr := address.Address{
+			Street:    req.Street,
+			Building:  req.Building,
+			Apartment: req.Apartment,
+			Entrance:  req.Entrance,
+			Floor:     req.Floor,
+			Intercom:  req.Intercom,
+			Comment:   req.Comment,
+		}.Normalize()
+		if err := addr.Validate(); err != nil {
+			response.Error(log, w, r, err.Error(), "invalid address", http.StatusBadRequest)
+			return
+		}
+
+		point, err := geocode.Locate(r.Context(), geocoder, addr.Query(), req.Location)
+		if errors.Is(err, geo.ErrInvalidPoint) || errors.Is(err, geocode.ErrNotFound) {
+			response.Error(log, w, r, err.Error(), "failed to locate address", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		var saved database.Address
+		err = creater.InTx(r.Context(), func(q *database.Queries) error {
+			if req.IsDefault {
+				if err := q.ClearDefaultAddress(r.Context(), user.ID); err != nil {
+					return err
+				}
+			}
+			saved, err = q.CreateAddress(r.Context(), database.CreateAddressParams{
+				UserID:    user.ID,
+				Street:    addr.Street,
+				Building:  addr.Building,
+				Apartment: addr.Apartment,
+				Entrance:  addr.Entrance,
+				Floor:     addr.Floor,
+				Intercom:  addr.Intercom,
+				Comment:   addr.Comment,
+				Lat:       point.Lat,
+				Lng:       point.Lng,
+				// zones are checked only against points the customer pinned or a real geocoder found
+				LocatedExactly: req.Location != nil || !geocode.IsApproximate(geocoder),
+				IsDefault:      req.IsDefault,
+			})
+			return err
+		})
+		if err != nil {
+			response.Error(log, w, r, "failed to save address", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("address saved", slog.Int("address_id", int(saved.ID)))
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, addressStruct.FromRow(saved))
+	}
+}
@@ -0,0 +1,82 @@
+package setDefaultAddress
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/addressStruct"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type defaultSetter interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
+}
+
+// Addresses godoc
+// @Summary Выбор адреса по умолчанию
+// @Description Делает адрес адресом по умолчанию, он используется в заказе, если не передан ни address_id, ни address
+// @Tags Addresses
+// @Produce json
+// @Param id path int true "ID адреса"
+// @Success 200 {object} addressStruct.Address "Адрес выбран"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Адрес не найден"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /users/me/addresses/{id}/default [patch]
+// @Security BearerAuth
+func New(log *slog.Logger, setter defaultSetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.addresses.setDefaultAddress"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		addressID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || addressID < 1 {
+			response.Error(log, w, r, "invalid address ID", "failed to parse address ID", http.StatusBadRequest)
+			return
+		}
+
+		var updated database.Address
+		err = setter.InTx(r.Context(), func(q *database.Queries) error {
+			// only one default address per user is allowed by a unique index
+			if err := q.ClearDefaultAddress(r.Context(), user.ID); err != nil {
+				return err
+			}
+			updated, err = q.SetDefaultAddress(r.Context(), database.SetDefaultAddressParams{
+				ID:     int32(addressID),
+				UserID: user.ID,
+			})
+			return err
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "address not found", "no address of user", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "failed to set default address", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("default address set", slog.Int("address_id", int(updated.ID)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, addressStruct.FromRow(updated))
+	}
+}
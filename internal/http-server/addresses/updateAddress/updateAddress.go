@@ -0,0 +1,157 @@
+package updateAddress
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"github.com/yourgfslove/GodFoodApi/internal/geocode"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/addressStruct"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request changes only the fields that are set. Without location the address is geocoded again
+// when the street or the building changes.
+type Request struct {
+	Street    *string    `json:"street,omitempty" example:"Tverskaya"`
+	Building  *string    `json:"building,omitempty" example:"7"`
+	Apartment *string    `json:"apartment,omitempty" example:"12"`
+	Entrance  *string    `json:"entrance,omitempty" example:"2"`
+	Floor     *string    `json:"floor,omitempty" example:"5"`
+	Intercom  *string    `json:"intercom,omitempty" example:"12K"`
+	Comment   *string    `json:"comment,omitempty" example:"call on arrival"`
+	Location  *geo.Point `json:"location,omitempty"`
+}
+
+type addressUpdater interface {
+	GetAddressByID(ctx context.Context, arg database.GetAddressByIDParams) (database.Address, error)
+	UpdateAddress(ctx context.Context, arg database.UpdateAddressParams) (database.Address, error)
+}
+
+// Addresses godoc
+// @Summary Изменение адреса
+// @Description Меняет переданные поля сохраненного адреса. Если изменились улица или дом и location не передан, координаты определяются заново. Заказы сохраняют адрес, с которым были оформлены
+// @Tags Addresses
+// @Accept json
+// @Produce json
+// @Param id path int true "ID адреса"
+// @Param request body updateAddress.Request true "Поля для изменения"
+// @Success 200 {object} addressStruct.Address "Адрес изменен"
+// @Failure 400 {object} response.Response "Некорректные данные или адрес не найден"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Адрес не найден"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /users/me/addresses/{id} [patch]
+// @Security BearerAuth
+func New(log *slog.Logger, updater addressUpdater, geocoder geocode.Geocoder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.addresses.updateAddress"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		addressID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || addressID < 1 {
+			response.Error(log, w, r, "invalid address ID", "failed to parse address ID", http.StatusBadRequest)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		current, err := updater.GetAddressByID(r.Context(), database.GetAddressByIDParams{
+			ID:     int32(addressID),
+			UserID: user.ID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "address not found", "no address of user", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		before := addressStruct.Parts(current)
+		addr := before
+		set(&addr.Street, req.Street)
+		set(&addr.Building, req.Building)
+		set(&addr.Apartment, req.Apartment)
+		set(&addr.Entrance, req.Entrance)
+		set(&addr.Floor, req.Floor)
+		set(&addr.Intercom, req.Intercom)
+		set(&addr.Comment, req.Comment)
+		addr = addr.Normalize()
+		if err := addr.Validate(); err != nil {
+			response.Error(log, w, r, err.Error(), "invalid address", http.StatusBadRequest)
+			return
+		}
+
+		point := geo.Point{Lat: current.Lat, Lng: current.Lng}
+		locatedExactly := current.LocatedExactly
+		if req.Location != nil || addr.Query() != before.Query() {
+			locatedExactly = req.Location != nil || !geocode.IsApproximate(geocoder)
+			point, err = geocode.Locate(r.Context(), geocoder, addr.Query(), req.Location)
+			if errors.Is(err, geo.ErrInvalidPoint) || errors.Is(err, geocode.ErrNotFound) {
+				response.Error(log, w, r, err.Error(), "failed to locate address", http.StatusBadRequest)
+				return
+			}
+			if err != nil {
+				response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		updated, err := updater.UpdateAddress(r.Context(), database.UpdateAddressParams{
+			Street:         addr.Street,
+			Building:       addr.Building,
+			Apartment:      addr.Apartment,
+			Entrance:       addr.Entrance,
+			Floor:          addr.Floor,
+			Intercom:       addr.Intercom,
+			Comment:        addr.Comment,
+			Lat:            point.Lat,
+			Lng:            point.Lng,
+			LocatedExactly: locatedExactly,
+			ID:             current.ID,
+			UserID:         user.ID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "address not found", "address deleted meanwhile", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "failed to update address", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("address updated", slog.Int("address_id", int(updated.ID)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, addressStruct.FromRow(updated))
+	}
+}
+
+func set(field *string, value *string) {
+	if value != nil {
+		*field = *value
+	}
+}
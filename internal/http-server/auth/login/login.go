@@ -2,6 +2,7 @@ package login
 
 import (
 	"context"
+	"database/sql"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
@@ -26,9 +27,8 @@ type loginResponse struct {
 	Email        string `json:"email" example:"user@example.com"`
 }
 
-type RefreshTokenSaverGetter interface {
+type RefreshTokenSaver interface {
 	CreateToken(ctx context.Context, arg database.CreateTokenParams) (database.CreateTokenRow, error)
-	GetTokensByUser(ctx context.Context, userID int32) ([]database.Refreshtoken, error)
 }
 
 type UserGetter interface {
@@ -47,7 +47,7 @@ type UserGetter interface {
 // @Fastringilure 401 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /login [post]
-func New(log *slog.Logger, saver RefreshTokenSaverGetter, userGetter UserGetter, tokenSecret string) http.HandlerFunc {
+func New(log *slog.Logger, saver RefreshTokenSaver, userGetter UserGetter, tokenSecret string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "http-server.auth.login"
 		log = log.With(
@@ -84,37 +84,25 @@ func New(log *slog.Logger, saver RefreshTokenSaverGetter, userGetter UserGetter,
 			return
 		}
 
-		refreshTokens, err := saver.GetTokensByUser(r.Context(), user.ID)
+		// every login starts its own session so it can be listed and revoked separately
+		userRefreshToken, err := refreshToken.MakeRefreshToken()
 		if err != nil {
-			response.Error(log, w, r, "something went wrong", "failed to get tokens by user", http.StatusInternalServerError)
+			response.Error(log, w, r, "something went wrong", "failed to make new RefreshToken", http.StatusInternalServerError)
 			return
 		}
-		var userRefreshToken string
-		for _, t := range refreshTokens {
-			if !t.RevokedAt.Valid && t.ExpiresAt.Time.After(time.Now()) {
-				userRefreshToken = t.Token
-				break
-			}
-		}
-
-		if userRefreshToken == "" {
-			userRefreshToken, err = refreshToken.MakeRefreshToken()
-			if err != nil {
-				response.Error(log, w, r, "something went wrong", "failed to make new RefreshToken", http.StatusInternalServerError)
-				return
-			}
-			_, err = saver.CreateToken(r.Context(), database.CreateTokenParams{
-				Token:  userRefreshToken,
-				UserID: user.ID,
-			})
-			if err != nil {
-				response.Error(log, w, r, "something went wrong", "failed to save refresh token", http.StatusInternalServerError)
-				return
-			}
-			log.Info("refresh token saved")
+		_, err = saver.CreateToken(r.Context(), database.CreateTokenParams{
+			Token:     userRefreshToken,
+			UserID:    user.ID,
+			UserAgent: sql.NullString{String: r.UserAgent(), Valid: r.UserAgent() != ""},
+			Ip:        refreshToken.ClientIP(r),
+		})
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", "failed to save refresh token", http.StatusInternalServerError)
+			return
 		}
+		log.Info("refresh token saved")
 
-		jwt, err := JWT.MakeJWT(user.ID, tokenSecret, time.Hour)
+		jwt, err := JWT.MakeJWT(user.ID, user.UserRole, tokenSecret, time.Hour)
 		if err != nil {
 			response.Error(log, w, r, "something went wrong", "failed to make jwt", http.StatusInternalServerError)
 			return
@@ -0,0 +1,83 @@
+package logout
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	RefreshToken string `json:"refresh_token" validate:"required" example:"7027102e5ddecf9dfaa1fa602851f7e77a212c486a37f014a5c016d3f3a2cdce"`
+}
+
+type TokenRevoker interface {
+	RevokeToken(ctx context.Context, arg database.RevokeTokenParams) (int64, error)
+}
+
+// Logout godoc
+// @Summary Выход
+// @Description Отзывает refresh-token текущей сессии авторизованного пользователя
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body logout.Request true "Refresh-token текущей сессии"
+// @Success 204 "Сессия завершена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 404 {object} response.Response "Сессия не найдена"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /logout [post]
+// @Security BearerAuth
+func New(log *slog.Logger, revoker TokenRevoker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http-server.auth.logout"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		revoked, err := revoker.RevokeToken(r.Context(), database.RevokeTokenParams{
+			Token:  req.RefreshToken,
+			UserID: user.ID,
+		})
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		if revoked == 0 {
+			response.Error(log, w, r, "session not found", "no active refresh token for user", http.StatusNotFound)
+			return
+		}
+
+		log.Info("refresh token revoked")
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,58 @@
+package logoutAll
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+type Response struct {
+	Revoked int64 `json:"revoked" example:"3"`
+}
+
+type TokensRevoker interface {
+	RevokeTokensByUser(ctx context.Context, userID int32) (int64, error)
+}
+
+// Logout godoc
+// @Summary Выход со всех устройств
+// @Description Отзывает все refresh-token'ы авторизованного пользователя
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} logoutAll.Response "Все сессии завершены"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /logout/all [post]
+// @Security BearerAuth
+func New(log *slog.Logger, revoker TokensRevoker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http-server.auth.logoutAll"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		revoked, err := revoker.RevokeTokensByUser(r.Context(), user.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("all refresh tokens revoked", slog.Int64("revoked", revoked))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			Revoked: revoked,
+		})
+	}
+}
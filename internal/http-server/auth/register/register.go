@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	_ "database/sql"
+	"fmt"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
@@ -11,6 +12,7 @@ import (
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/JWT"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/hashPassword"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/refreshToken"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/validation/phoneValidation"
@@ -36,8 +38,9 @@ type Response struct {
 	Name         string `json:"name" example:"Bill"`
 }
 
+// UserSaver creates the user and, for the restaurant role, the restaurant it owns in one transaction.
 type UserSaver interface {
-	CreateUser(ctx context.Context, arg database.CreateUserParams) (database.User, error)
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
 }
 
 type RefreshTokenSaver interface {
@@ -90,18 +93,43 @@ func New(log *slog.Logger, saver UserSaver, tokenSaver RefreshTokenSaver, tokenS
 			return
 		}
 
-		savedUser, err := saver.CreateUser(r.Context(), database.CreateUserParams{
-			Email:        req.Email,
-			HashPassword: hashedPassword,
-			UserRole:     req.Role,
-			Phone:        req.Phone,
-			Address: sql.NullString{
-				String: req.Address,
-				Valid:  req.Address != ""},
-			UserName: sql.NullString{
-				String: req.Name,
-				Valid:  req.Name != "",
-			},
+		var savedUser database.User
+		err = saver.InTx(r.Context(), func(q *database.Queries) error {
+			savedUser, err = q.CreateUser(r.Context(), database.CreateUserParams{
+				Email:        req.Email,
+				HashPassword: hashedPassword,
+				UserRole:     req.Role,
+				Phone:        req.Phone,
+				Address: sql.NullString{
+					String: req.Address,
+					Valid:  req.Address != ""},
+				UserName: sql.NullString{
+					String: req.Name,
+					Valid:  req.Name != "",
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("create user: %w", err)
+			}
+			if req.Role != principal.RoleRestaurant {
+				return nil
+			}
+			restaurant, err := q.CreateRestaurant(r.Context(), database.CreateRestaurantParams{
+				Name:    req.Name,
+				Address: req.Address,
+				Phone:   req.Phone,
+			})
+			if err != nil {
+				return fmt.Errorf("create restaurant: %w", err)
+			}
+			if err := q.AddRestaurantStaff(r.Context(), database.AddRestaurantStaffParams{
+				RestaurantID: restaurant.ID,
+				UserID:       savedUser.ID,
+				StaffRole:    principal.StaffRoleOwner,
+			}); err != nil {
+				return fmt.Errorf("add restaurant owner: %w", err)
+			}
+			return nil
 		})
 		if err != nil {
 			response.Error(log, w, r, "failed to create user", "failed to create user", http.StatusInternalServerError)
@@ -114,13 +142,16 @@ func New(log *slog.Logger, saver UserSaver, tokenSaver RefreshTokenSaver, tokenS
 			return
 		}
 		savedToken, err := tokenSaver.CreateToken(r.Context(), database.CreateTokenParams{
-			UserID: savedUser.ID,
-			Token:  newRefreshToken})
+			UserID:    savedUser.ID,
+			Token:     newRefreshToken,
+			UserAgent: sql.NullString{String: r.UserAgent(), Valid: r.UserAgent() != ""},
+			Ip:        refreshToken.ClientIP(r),
+		})
 		if err != nil {
 			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
 			return
 		}
-		newJWT, err := JWT.MakeJWT(savedUser.ID, tokenSecret, time.Hour)
+		newJWT, err := JWT.MakeJWT(savedUser.ID, savedUser.UserRole, tokenSecret, time.Hour)
 		if err != nil {
 			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
 			return
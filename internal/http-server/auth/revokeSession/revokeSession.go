@@ -0,0 +1,69 @@
+package revokeSession
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type SessionRevoker interface {
+	RevokeSession(ctx context.Context, arg database.RevokeSessionParams) (int64, error)
+}
+
+// RevokeSession godoc
+// @Summary Завершение сессии
+// @Description Отзывает сессию авторизованного пользователя по идентификатору из списка сессий, refresh-token для этого не нужен
+// @Tags auth
+// @Produce json
+// @Param id path int true "ID сессии"
+// @Success 204 "Сессия завершена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 404 {object} response.Response "Сессия не найдена"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /sessions/{id} [delete]
+// @Security BearerAuth
+func New(log *slog.Logger, revoker SessionRevoker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http-server.auth.revokeSession"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		sessionID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil || sessionID < 1 {
+			response.Error(log, w, r, "invalid session ID", "failed to parse session ID", http.StatusBadRequest)
+			return
+		}
+
+		revoked, err := revoker.RevokeSession(r.Context(), database.RevokeSessionParams{
+			ID:     sessionID,
+			UserID: user.ID,
+		})
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		if revoked == 0 {
+			response.Error(log, w, r, "session not found", "no active session of user", http.StatusNotFound)
+			return
+		}
+
+		log.Info("session revoked", slog.Int64("session_id", sessionID))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
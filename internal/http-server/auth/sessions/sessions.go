@@ -0,0 +1,79 @@
+package sessions
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type Response struct {
+	Sessions []Session `json:"sessions"`
+}
+
+type Session struct {
+	ID        int64  `json:"id" example:"42"`
+	CreatedAt string `json:"created_at" example:"2025-06-17T00:25:16Z"`
+	ExpiresAt string `json:"expires_at" example:"2025-06-27T00:25:16Z"`
+	UserAgent string `json:"user_agent,omitempty" example:"okhttp/4.12.0"`
+	IP        string `json:"ip,omitempty" example:"192.168.1.10"`
+}
+
+type TokensGetter interface {
+	GetActiveTokensByUser(ctx context.Context, userID int32) ([]database.Refreshtoken, error)
+}
+
+// Sessions godoc
+// @Summary Активные сессии
+// @Description Возвращает активные refresh-token'ы авторизованного пользователя с идентификатором, временем создания, истечения, user-agent и IP
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Success 200 {object} sessions.Response "Сессии успешно получены"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /sessions [get]
+// @Security BearerAuth
+func New(log *slog.Logger, getter TokensGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http-server.auth.sessions"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		tokens, err := getter.GetActiveTokensByUser(r.Context(), user.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		sessionList := make([]Session, 0, len(tokens))
+		for _, t := range tokens {
+			sessionList = append(sessionList, Session{
+				ID:        t.ID,
+				CreatedAt: t.CreatedAt.Time.Format(time.RFC3339),
+				ExpiresAt: t.ExpiresAt.Time.Format(time.RFC3339),
+				UserAgent: t.UserAgent.String,
+				IP:        t.Ip.String,
+			})
+		}
+
+		log.Info("got sessions")
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			Sessions: sessionList,
+		})
+	}
+}
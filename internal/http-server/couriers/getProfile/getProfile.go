@@ -0,0 +1,96 @@
+package getProfile
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type Response struct {
+	ID          int32  `json:"id" example:"7"`
+	Name        string `json:"name" example:"Bill"`
+	Phone       string `json:"phone" example:"89056666666"`
+	MemberSince string `json:"member_since" example:"Mon, 02 Jan 2006 15:04:05 UTC"`
+	Status      string `json:"status" example:"online"`
+	StatusSince string `json:"status_since,omitempty" example:"Mon, 02 Jan 2006 15:04:05 UTC"`
+	Stats       Stats  `json:"stats"`
+}
+
+// Stats are lifetime counters of the courier.
+type Stats struct {
+	OrdersDelivered int32 `json:"orders_delivered" example:"120"`
+	OffersAccepted  int32 `json:"offers_accepted" example:"95"`
+	OffersDeclined  int32 `json:"offers_declined" example:"4"`
+	OffersExpired   int32 `json:"offers_expired" example:"2"`
+}
+
+type profileGetter interface {
+	GetCourierProfile(ctx context.Context, id int32) (database.GetCourierProfileRow, error)
+}
+
+// Couriers godoc
+// @Summary Профиль курьера
+// @Description Возвращает статус смены авторизованного курьера и его статистику за все время
+// @Tags Couriers
+// @Produce json
+// @Success 200 {object} getProfile.Response "Профиль получен"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Курьер не найден"
+// @Failure 500 {object} response.Response "Ошибка сервера"
+// @Router /couriers/me [get]
+// @Security BearerAuth
+func New(log *slog.Logger, getter profileGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http-server.couriers.getProfile"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		profile, err := getter.GetCourierProfile(r.Context(), user.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "Courier not found", "no user", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		resp := Response{
+			ID:          profile.ID,
+			Name:        profile.UserName.String,
+			Phone:       profile.Phone,
+			MemberSince: profile.CreatedAt.Format(time.RFC1123),
+			Status:      profile.Status,
+			Stats: Stats{
+				OrdersDelivered: profile.OrdersDelivered,
+				OffersAccepted:  profile.OffersAccepted,
+				OffersDeclined:  profile.OffersDeclined,
+				OffersExpired:   profile.OffersExpired,
+			},
+		}
+		if profile.StatusChangedAt.Valid {
+			resp.StatusSince = profile.StatusChangedAt.Time.Format(time.RFC1123)
+		}
+
+		log.Info("courier profile sent")
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, resp)
+	}
+}
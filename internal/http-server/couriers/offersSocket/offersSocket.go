@@ -0,0 +1,58 @@
+package offersSocket
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/gorilla/websocket"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+type hub interface {
+	Serve(ctx context.Context, conn *websocket.Conn, courierID int32)
+}
+
+// Couriers godoc
+// @Summary Канал предложений заказов (WebSocket)
+// @Description WebSocket, по которому курьер получает предложения заказов вместо опроса /orders/pending. Авторизация обычным JWT в заголовке Authorization. Сервер шлет JSON-сообщения с полем type: offer (новое предложение, тело как в GET /orders/offer, при подключении приходит текущее открытое предложение), offer_withdrawn (предложение снято, reason: taken - заказ взял другой курьер, cancelled - заказ отменен, expired - время вышло), assigned (заказ назначен на курьера). Принимать и отклонять предложения нужно через HTTP, сообщения клиента игнорируются. Сервер шлет ping раз в 54 секунды и закрывает соединение без pong за 60 секунд. Клиент, который не успевает читать сообщения, отключается с кодом 1013 и должен переподключиться
+// @Tags Couriers
+// @Produce json
+// @Success 101 {object} courierHub.Message "Соединение установлено, дальше идут сообщения"
+// @Failure 400 {object} response.Response "Не WebSocket запрос"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Router /couriers/me/offers/ws [get]
+// @Security BearerAuth
+func New(log *slog.Logger, hub hub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http-server.couriers.offersSocket"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		// Upgrade answers the client itself when the handshake is wrong
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.Info("failed to upgrade connection", sl.Err(err))
+			return
+		}
+
+		log.Info("offers socket opened", slog.Int("courier_id", int(user.ID)))
+		hub.Serve(r.Context(), conn, user.ID)
+		log.Info("offers socket closed", slog.Int("courier_id", int(user.ID)))
+	}
+}
@@ -0,0 +1,144 @@
+package postLocation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// trailLength is how many latest fixes are kept per order.
+const trailLength = 500
+
+type Request struct {
+	Lat      *float64 `json:"lat" validate:"required" example:"55.7558"`
+	Lng      *float64 `json:"lng" validate:"required" example:"37.6173"`
+	Accuracy *float64 `json:"accuracy,omitempty" example:"12.5"`
+}
+
+type Response struct {
+	Location  geo.Point `json:"location"`
+	UpdatedAt string    `json:"updated_at" example:"Mon, 02 Jan 2006 15:04:05 UTC"`
+	// OrderID is the order the fix was added to the trail of, zero without an active order.
+	OrderID int32 `json:"order_id,omitempty" example:"12"`
+}
+
+type locationSaver interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
+}
+
+// Couriers godoc
+// @Summary Отправка координат курьера
+// @Description Курьер на линии периодически отправляет GPS координаты. Последняя позиция сохраняется всегда, а пока у курьера есть заказ, точка добавляется в трек заказа (хранятся последние 500 точек)
+// @Tags Couriers
+// @Accept json
+// @Produce json
+// @Param request body postLocation.Request true "Координаты в градусах и точность в метрах"
+// @Success 200 {object} postLocation.Response "Координаты сохранены"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 500 {object} response.Response "Ошибка сервера"
+// @Router /couriers/me/location [post]
+// @Security BearerAuth
+func New(log *slog.Logger, saver locationSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http-server.couriers.postLocation"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		point := geo.Point{Lat: *req.Lat, Lng: *req.Lng}
+		if err := point.Validate(); err != nil {
+			response.Error(log, w, r, err.Error(), "invalid coordinates", http.StatusBadRequest)
+			return
+		}
+		accuracy := sql.NullFloat64{}
+		if req.Accuracy != nil {
+			if *req.Accuracy < 0 {
+				response.Error(log, w, r, "accuracy can't be negative", "invalid accuracy", http.StatusBadRequest)
+				return
+			}
+			accuracy = sql.NullFloat64{Float64: *req.Accuracy, Valid: true}
+		}
+
+		var location database.CourierLocation
+		var orderID int32
+		err := saver.InTx(r.Context(), func(q *database.Queries) error {
+			var err error
+			location, err = q.UpsertCourierLocation(r.Context(), database.UpsertCourierLocationParams{
+				CourierID: user.ID,
+				Lat:       point.Lat,
+				Lng:       point.Lng,
+				Accuracy:  accuracy,
+			})
+			if err != nil {
+				return err
+			}
+
+			orderID, err = q.GetCurrentIDOrderForCourier(r.Context(), sql.NullInt32{Int32: user.ID, Valid: true})
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := q.AddTrackingPoint(r.Context(), database.AddTrackingPointParams{
+				OrderID:   orderID,
+				CourierID: user.ID,
+				Lat:       point.Lat,
+				Lng:       point.Lng,
+			}); err != nil {
+				return err
+			}
+			return q.TrimTrackingPoints(r.Context(), database.TrimTrackingPointsParams{
+				OrderID: orderID,
+				Keep:    trailLength,
+			})
+		})
+		if err != nil {
+			response.Error(log, w, r, "Can not save location", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Debug("courier location saved", slog.Int("order_id", int(orderID)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			Location:  geo.Point{Lat: location.Lat, Lng: location.Lng},
+			UpdatedAt: location.UpdatedAt.Format(time.RFC1123),
+			OrderID:   orderID,
+		})
+	}
+}
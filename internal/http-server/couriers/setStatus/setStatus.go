@@ -0,0 +1,159 @@
+package setStatus
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/courierStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type Request struct {
+	Status string `json:"status" validate:"required" example:"online"`
+}
+
+type Response struct {
+	Status      string `json:"status" example:"online"`
+	StatusSince string `json:"status_since" example:"Mon, 02 Jan 2006 15:04:05 UTC"`
+}
+
+type statusSetter interface {
+	SetCourierStatus(ctx context.Context, arg database.SetCourierStatusParams) (database.Couriersstat, error)
+}
+
+type currentOrderGetter interface {
+	GetCurrentIDOrderForCourier(ctx context.Context, courierid sql.NullInt32) (int32, error)
+}
+
+type offerExpirer interface {
+	GetOpenOfferForCourier(ctx context.Context, courierID int32) (database.CourierOffer, error)
+	ExpireCourierOffer(ctx context.Context, id int32) (int64, error)
+}
+
+type readyOrdersGetter interface {
+	GetUndispatchedReadyOrderIDs(ctx context.Context) ([]int32, error)
+}
+
+type dispatcher interface {
+	Dispatch(orderID int32)
+	Answered(orderID int32)
+}
+
+// Couriers godoc
+// @Summary Смена статуса смены курьера
+// @Description Курьер выходит на линию (online), уходит на перерыв (on_break) или заканчивает смену (offline). Заказы и предложения получают только курьеры online, при выходе на линию им сразу предлагаются ждущие курьера готовые заказы. Уйти с линии можно только после доставки текущего заказа
+// @Tags Couriers
+// @Accept json
+// @Produce json
+// @Param request body setStatus.Request true "Новый статус: online, offline или on_break"
+// @Success 200 {object} setStatus.Response "Статус изменен"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 409 {object} response.Response "Есть недоставленный заказ"
+// @Failure 500 {object} response.Response "Ошибка сервера"
+// @Router /couriers/me/status [patch]
+// @Security BearerAuth
+func New(
+	log *slog.Logger,
+	setter statusSetter,
+	getterCurrent currentOrderGetter,
+	offers offerExpirer,
+	readyOrders readyOrdersGetter,
+	dispatcher dispatcher,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http-server.couriers.setStatus"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		status := courierStatus.Status(req.Status)
+		if !status.IsValid() {
+			response.Error(log, w, r, "status must be online, offline or on_break", "invalid courier status", http.StatusBadRequest)
+			return
+		}
+
+		if !status.CanTakeOrders() {
+			_, err := getterCurrent.GetCurrentIDOrderForCourier(r.Context(), sql.NullInt32{Int32: user.ID, Valid: true})
+			if err == nil {
+				response.Error(log, w, r, "Deliver the current order first", "courier has an active order", http.StatusConflict)
+				return
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		stats, err := setter.SetCourierStatus(r.Context(), database.SetCourierStatusParams{
+			ID:     user.ID,
+			Status: status.String(),
+		})
+		if err != nil {
+			response.Error(log, w, r, "Can not change status", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		// an open offer goes to the next courier right away instead of waiting for its timeout
+		if !status.CanTakeOrders() {
+			offer, err := offers.GetOpenOfferForCourier(r.Context(), user.ID)
+			if err == nil {
+				if _, err := offers.ExpireCourierOffer(r.Context(), offer.ID); err != nil {
+					log.Error("failed to expire offer", sl.Err(err))
+				}
+				dispatcher.Answered(offer.OrderID)
+			} else if !errors.Is(err, sql.ErrNoRows) {
+				log.Error("failed to get open offer", sl.Err(err))
+			}
+		}
+
+		// ready orders whose dispatch ran out of couriers get another round now that someone is available
+		if status.CanTakeOrders() {
+			orderIDs, err := readyOrders.GetUndispatchedReadyOrderIDs(r.Context())
+			if err != nil {
+				log.Error("failed to get ready orders", sl.Err(err))
+			}
+			for _, id := range orderIDs {
+				dispatcher.Dispatch(id)
+			}
+		}
+
+		log.Info("courier status changed", slog.String("status", stats.Status))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			Status:      stats.Status,
+			StatusSince: stats.StatusChangedAt.Format(time.RFC1123),
+		})
+	}
+}
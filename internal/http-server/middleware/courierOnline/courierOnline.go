@@ -0,0 +1,46 @@
+package courierOnline
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/courierStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+type statusGetter interface {
+	GetCourierStatus(ctx context.Context, id int32) (string, error)
+}
+
+// New lets only couriers on shift through. Couriers who never set a status count as offline.
+// Must be mounted after AuthJWTMiddleware.
+func New(log *slog.Logger, getter statusGetter) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := principal.FromContext(r.Context())
+			if !ok {
+				response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+				return
+			}
+
+			status, err := getter.GetCourierStatus(r.Context(), user.ID)
+			if errors.Is(err, sql.ErrNoRows) {
+				status = courierStatus.Offline.String()
+			} else if err != nil {
+				response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+				return
+			}
+
+			if !courierStatus.Status(status).CanTakeOrders() {
+				response.Error(log, w, r, "Go online to take orders", "courier is "+status, http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+const (
+	Header         = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+	maxKeyLength   = 255
+	// maxBodyBytes caps the body read into memory for hashing, order requests are far smaller
+	maxBodyBytes = 1 << 20
+)
+
+type keyStore interface {
+	CreateIdempotencyKey(ctx context.Context, arg database.CreateIdempotencyKeyParams) (database.IdempotencyKey, error)
+	GetIdempotencyKey(ctx context.Context, arg database.GetIdempotencyKeyParams) (database.IdempotencyKey, error)
+	SaveIdempotencyResponse(ctx context.Context, arg database.SaveIdempotencyResponseParams) error
+	DeleteIdempotencyKey(ctx context.Context, arg database.DeleteIdempotencyKeyParams) error
+}
+
+// New replays the stored response when a client retries a request with the same Idempotency-Key.
+// Must be mounted after AuthJWTMiddleware: keys are scoped to the principal.
+// A key left in progress by a crashed server is taken over by the next request after a minute.
+func New(log *slog.Logger, store keyStore) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const op = "http-server.middleware.idempotency"
+
+			key := r.Header.Get(Header)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			log := log.With(
+				slog.String("op", op),
+				slog.String("request_id", middleware.GetReqID(r.Context())))
+
+			if len(key) > maxKeyLength {
+				response.Error(log, w, r, "Idempotency-Key is too long", "idempotency key too long", http.StatusBadRequest)
+				return
+			}
+
+			user, ok := principal.FromContext(r.Context())
+			if !ok {
+				response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				response.Error(log, w, r, "request body is too large", sl.Err(err).String(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			if err != nil {
+				response.Error(log, w, r, "failed to read request body", sl.Err(err).String(), http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			hash := requestHash(r, body)
+
+			_, err = store.CreateIdempotencyKey(r.Context(), database.CreateIdempotencyKeyParams{
+				Key:         key,
+				UserID:      user.ID,
+				RequestHash: hash,
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				replay(log, w, r, store, key, user.ID, hash)
+				return
+			}
+			if err != nil {
+				response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+				return
+			}
+
+			release := func() {
+				if err := store.DeleteIdempotencyKey(context.WithoutCancel(r.Context()), database.DeleteIdempotencyKeyParams{
+					Key:    key,
+					UserID: user.ID,
+				}); err != nil {
+					log.Error("failed to delete idempotency key", sl.Err(err))
+				}
+			}
+			// a panicking handler would leave the key in progress, the recoverer up the chain still answers the client
+			defer func() {
+				if rvr := recover(); rvr != nil {
+					release()
+					panic(rvr)
+				}
+			}()
+
+			var buf bytes.Buffer
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&buf)
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			// a server error is not a result worth replaying, let the client try again
+			if status >= http.StatusInternalServerError {
+				release()
+				return
+			}
+
+			if err := store.SaveIdempotencyResponse(context.WithoutCancel(r.Context()), database.SaveIdempotencyResponseParams{
+				Key:          key,
+				UserID:       user.ID,
+				StatusCode:   sql.NullInt32{Int32: int32(status), Valid: true},
+				ResponseBody: buf.Bytes(),
+			}); err != nil {
+				log.Error("failed to save idempotent response", sl.Err(err))
+			}
+		})
+	}
+}
+
+func replay(log *slog.Logger, w http.ResponseWriter, r *http.Request, store keyStore, key string, userID int32, hash string) {
+	stored, err := store.GetIdempotencyKey(r.Context(), database.GetIdempotencyKeyParams{
+		Key:    key,
+		UserID: userID,
+	})
+	if err != nil {
+		response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+		return
+	}
+
+	if stored.RequestHash != hash {
+		response.Error(log, w, r,
+			"Idempotency-Key was already used with a different request",
+			"idempotency key reused with different body",
+			http.StatusConflict)
+		return
+	}
+
+	if !stored.StatusCode.Valid {
+		response.Error(log, w, r,
+			"request with this Idempotency-Key is still in progress",
+			"idempotency key in progress",
+			http.StatusConflict)
+		return
+	}
+
+	log.Info("replaying idempotent response")
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(int(stored.StatusCode.Int32))
+	if _, err := w.Write(stored.ResponseBody); err != nil {
+		log.Error("failed to write replayed response", sl.Err(err))
+	}
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte(r.URL.Path))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
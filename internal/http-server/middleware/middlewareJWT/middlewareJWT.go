@@ -1,12 +1,13 @@
 package middlewareJWT
 
 import (
-	"context"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/JWT"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/getToken"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
 	"log/slog"
 	"net/http"
+	"slices"
 )
 
 func AuthJWTMiddleware(log *slog.Logger, tokenSecret string) func(next http.Handler) http.Handler {
@@ -21,7 +22,7 @@ func AuthJWTMiddleware(log *slog.Logger, tokenSecret string) func(next http.Hand
 				return
 			}
 
-			userID, err := JWT.ValidateJWT(token, tokenSecret)
+			userID, role, err := JWT.ValidateJWT(token, tokenSecret)
 			if err != nil {
 				response.Error(log, w, r,
 					"failed to validate the token",
@@ -29,8 +30,36 @@ func AuthJWTMiddleware(log *slog.Logger, tokenSecret string) func(next http.Hand
 					http.StatusUnauthorized)
 				return
 			}
-			ctx := context.WithValue(r.Context(), "userID", userID)
+			ctx := principal.WithPrincipal(r.Context(), principal.Principal{
+				ID:   userID,
+				Role: role,
+			})
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// RequireRole lets the request through only if the principal set by AuthJWTMiddleware has one of the roles.
+func RequireRole(log *slog.Logger, roles ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := principal.FromContext(r.Context())
+			if !ok {
+				response.Error(log, w, r,
+					"Not authorized",
+					"no principal in context",
+					http.StatusUnauthorized)
+				return
+			}
+
+			if !slices.Contains(roles, user.Role) {
+				response.Error(log, w, r,
+					"access denied",
+					"wrong role",
+					http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package restaurantStaff
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+type staffGetter interface {
+	GetRestaurantStaffByUserID(ctx context.Context, userID int32) (database.RestaurantStaff, error)
+}
+
+// New looks up the restaurant the authenticated user works for and stores it with the staff role in the principal.
+// Must be mounted after AuthJWTMiddleware.
+func New(log *slog.Logger, getter staffGetter) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := principal.FromContext(r.Context())
+			if !ok {
+				response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+				return
+			}
+
+			staff, err := getter.GetRestaurantStaffByUserID(r.Context(), user.ID)
+			if errors.Is(err, sql.ErrNoRows) {
+				response.Error(log, w, r, "Access denied", "user is not a restaurant staff member", http.StatusForbidden)
+				return
+			}
+			if err != nil {
+				response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+				return
+			}
+
+			user.RestaurantID = staff.RestaurantID
+			user.StaffRole = staff.StaffRole
+			next.ServeHTTP(w, r.WithContext(principal.WithPrincipal(r.Context(), user)))
+		})
+	}
+}
+
+// RequireOwner lets the request through only for the owner of the restaurant. Must be mounted after New.
+func RequireOwner(log *slog.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := principal.FromContext(r.Context())
+			if !ok {
+				response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+				return
+			}
+
+			if user.StaffRole != principal.StaffRoleOwner {
+				response.Error(log, w, r, "Access denied", "user is not the restaurant owner", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
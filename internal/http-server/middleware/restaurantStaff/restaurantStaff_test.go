@@ -0,0 +1,72 @@
+package restaurantStaff
+
+import (
+	"context"
+	"database/sql"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fakeStaff map[int32]database.RestaurantStaff
+
+func (s fakeStaff) GetRestaurantStaffByUserID(_ context.Context, userID int32) (database.RestaurantStaff, error) {
+	staff, ok := s[userID]
+	if !ok {
+		return database.RestaurantStaff{}, sql.ErrNoRows
+	}
+	return staff, nil
+}
+
+func TestRequireOwner(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	staff := fakeStaff{
+		1: {RestaurantID: 7, UserID: 1, StaffRole: principal.StaffRoleOwner},
+		2: {RestaurantID: 7, UserID: 2, StaffRole: principal.StaffRoleStaff},
+	}
+
+	testcases := []struct {
+		name       string
+		userID     int32
+		ownerOnly  bool
+		wantStatus int
+	}{
+		{name: "owner", userID: 1, wantStatus: http.StatusOK},
+		{name: "staff", userID: 2, wantStatus: http.StatusOK},
+		{name: "not staff", userID: 3, wantStatus: http.StatusForbidden},
+		{name: "owner on owner route", userID: 1, ownerOnly: true, wantStatus: http.StatusOK},
+		{name: "staff on owner route", userID: 2, ownerOnly: true, wantStatus: http.StatusForbidden},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			var got principal.Principal
+			var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = principal.FromContext(r.Context())
+			})
+			if testcase.ownerOnly {
+				handler = RequireOwner(log)(handler)
+			}
+			handler = New(log, staff)(handler)
+
+			r := httptest.NewRequest(http.MethodGet, "/restaurants/me/staff", nil)
+			r = r.WithContext(principal.WithPrincipal(r.Context(), principal.Principal{
+				ID:   testcase.userID,
+				Role: principal.RoleRestaurant,
+			}))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != testcase.wantStatus {
+				t.Fatalf("got status %d, want %d", w.Code, testcase.wantStatus)
+			}
+			if w.Code == http.StatusOK && got.RestaurantID != 7 {
+				t.Fatalf("got restaurant %d, want 7", got.RestaurantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package cancelOrder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/orderStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/events"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type Request struct {
+	Reason string `json:"reason,omitempty" validate:"max=500" example:"changed my mind"`
+}
+
+type Response struct {
+	OrderID int32  `json:"order_id" example:"12"`
+	Status  string `json:"status" example:"cancelled"`
+}
+
+type orderGetter interface {
+	GetOrderByID(ctx context.Context, id int32) (database.Order, error)
+}
+
+// orderCanceller changes the order and records who did it and why in one transaction, so an order never
+// ends up cancelled without a record.
+type orderCanceller interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
+}
+
+type publisher interface {
+	Publish(ctx context.Context, orderID int32, eventType, status string)
+}
+
+// Orders godoc
+// @Summary Отмена заказа покупателем
+// @Description Покупатель отменяет свой заказ, пока ресторан его не принял. Причина необязательна
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "ID Заказа"
+// @Param request body cancelOrder.Request false "Причина отмены"
+// @Success 200 {object} cancelOrder.Response "Заказ отменен"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Заказ не найден"
+// @Failure 409 {object} response.Response "Заказ уже нельзя отменить"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /orders/{id}/cancel [patch]
+// @Security BearerAuth
+func New(
+	log *slog.Logger,
+	getter orderGetter,
+	canceller orderCanceller,
+	publisher publisher,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http_server.orders.cancelOrder.New"
+		log = log.With(slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		orderID := chi.URLParam(r, "id")
+		if orderID == "" {
+			response.Error(log, w, r, "Missing orderID", "no order id provided", http.StatusBadRequest)
+			return
+		}
+
+		parsedOrderID, err := strconv.ParseInt(orderID, 10, 32)
+		if err != nil || parsedOrderID < 1 {
+			response.Error(log, w, r, "Wrong orderID", "Invalid orderID", http.StatusBadRequest)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil && !errors.Is(err, io.EOF) {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		order, err := getter.GetOrderByID(r.Context(), int32(parsedOrderID))
+		if err != nil {
+			response.Error(log, w, r, "No order", "cannot get order", http.StatusNotFound)
+			return
+		}
+
+		if order.Customerid != user.ID {
+			response.Error(log, w, r, "Access denied", "not matching ids", http.StatusForbidden)
+			return
+		}
+
+		if !orderStatus.CustomerCanCancel(orderStatus.Status(order.Status)) {
+			response.Error(log, w, r, "Order can not be cancelled anymore", "wrong order status", http.StatusConflict)
+			return
+		}
+
+		var updated database.Order
+		err = canceller.InTx(r.Context(), func(q *database.Queries) error {
+			updated, err = q.SetOrderStatus(r.Context(), database.SetOrderStatusParams{
+				NewStatus: orderStatus.Cancelled.String(),
+				ID:        order.ID,
+				OldStatus: order.Status,
+			})
+			if err != nil {
+				return err
+			}
+			_, err = q.CreateOrderCancellation(r.Context(), database.CreateOrderCancellationParams{
+				OrderID:  order.ID,
+				UserID:   user.ID,
+				UserRole: user.Role,
+				Reason:   sql.NullString{String: req.Reason, Valid: req.Reason != ""},
+			})
+			return err
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "Order can not be cancelled anymore", "status changed concurrently", http.StatusConflict)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "Can not cancel order", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		publisher.Publish(r.Context(), updated.ID, events.TypeStatusChanged, updated.Status)
+
+		log.Info("order cancelled")
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			OrderID: updated.ID,
+			Status:  updated.Status,
+		})
+	}
+}
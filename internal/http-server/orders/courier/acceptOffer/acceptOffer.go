@@ -0,0 +1,142 @@
+package acceptOffer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/dispatch"
+	"github.com/yourgfslove/GodFoodApi/internal/events"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// errOrderTaken means the order got a courier or left the assignable statuses while the offer was open.
+var errOrderTaken = errors.New("order is no longer available")
+
+type Response struct {
+	OfferID int32  `json:"offer_id" example:"4"`
+	OrderID int32  `json:"order_id" example:"12"`
+	Status  string `json:"status" example:"accepted"`
+}
+
+type offerAccepter interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
+}
+
+type currentOrderGetter interface {
+	GetCurrentIDOrderForCourier(ctx context.Context, courierid sql.NullInt32) (int32, error)
+}
+
+type dispatcher interface {
+	Answered(orderID int32)
+	Assigned(orderID, courierID int32, status string)
+}
+
+type publisher interface {
+	Publish(ctx context.Context, orderID int32, eventType, status string)
+}
+
+// Orders godoc
+// @Summary Принятие предложения заказа
+// @Description Курьер принимает предложенный диспетчером заказ, заказ назначается на него. Детали заказа доступны в /orders/current
+// @Tags Orders
+// @Produce json
+// @Param id path int true "ID предложения"
+// @Success 200 {object} acceptOffer.Response "Заказ назначен"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Предложение не найдено или истекло"
+// @Failure 409 {object} response.Response "Заказ уже недоступен"
+// @Failure 500 {object} response.Response "Ошибка сервера"
+// @Router /orders/offer/{id}/accept [patch]
+// @Security BearerAuth
+func New(
+	log *slog.Logger,
+	accepter offerAccepter,
+	getterCurrent currentOrderGetter,
+	dispatcher dispatcher,
+	publisher publisher,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http_server.orders.courier.acceptOffer.New"
+		log = log.With(slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		offerID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || offerID < 1 {
+			response.Error(log, w, r, "invalid offer ID", "failed to parse offer ID", http.StatusBadRequest)
+			return
+		}
+
+		_, err = getterCurrent.GetCurrentIDOrderForCourier(r.Context(), sql.NullInt32{
+			Int32: user.ID,
+			Valid: true,
+		})
+		if err == nil {
+			response.Error(log, w, r, "already have order", "already have order", http.StatusForbidden)
+			return
+		}
+
+		var offer database.CourierOffer
+		var status string
+		err = accepter.InTx(r.Context(), func(q *database.Queries) error {
+			offer, err = q.RespondCourierOffer(r.Context(), database.RespondCourierOfferParams{
+				Status:    dispatch.OfferAccepted,
+				ID:        int32(offerID),
+				CourierID: user.ID,
+			})
+			if err != nil {
+				return err
+			}
+
+			status, err = q.AssignCourier(r.Context(), database.AssignCourierParams{
+				Courierid: sql.NullInt32{Int32: user.ID, Valid: true},
+				ID:        offer.OrderID,
+			})
+			if errors.Is(err, sql.ErrNoRows) {
+				return errOrderTaken
+			}
+			return err
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "offer not found or expired", "no open offer", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, errOrderTaken) {
+			// let the dispatcher notice right away that the order is gone
+			dispatcher.Answered(offer.OrderID)
+			response.Error(log, w, r, "Order is no longer available", "order taken while offered", http.StatusConflict)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "Can not accept offer", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		dispatcher.Assigned(offer.OrderID, user.ID, status)
+		publisher.Publish(r.Context(), offer.OrderID, events.TypeCourierAssigned, status)
+
+		log.Info("offer accepted", slog.Int("order_id", int(offer.OrderID)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			OfferID: offer.ID,
+			OrderID: offer.OrderID,
+			Status:  offer.Status,
+		})
+	}
+}
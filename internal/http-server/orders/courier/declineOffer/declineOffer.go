@@ -0,0 +1,90 @@
+package declineOffer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/dispatch"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type Response struct {
+	OfferID int32  `json:"offer_id" example:"4"`
+	OrderID int32  `json:"order_id" example:"12"`
+	Status  string `json:"status" example:"declined"`
+}
+
+type offerResponder interface {
+	RespondCourierOffer(ctx context.Context, arg database.RespondCourierOfferParams) (database.CourierOffer, error)
+}
+
+type dispatcher interface {
+	Answered(orderID int32)
+}
+
+// Orders godoc
+// @Summary Отказ от предложения заказа
+// @Description Курьер отказывается от предложенного заказа, диспетчер сразу предлагает его следующему курьеру
+// @Tags Orders
+// @Produce json
+// @Param id path int true "ID предложения"
+// @Success 200 {object} declineOffer.Response "Предложение отклонено"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Предложение не найдено или истекло"
+// @Failure 500 {object} response.Response "Ошибка сервера"
+// @Router /orders/offer/{id}/decline [patch]
+// @Security BearerAuth
+func New(log *slog.Logger, responder offerResponder, dispatcher dispatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http_server.orders.courier.declineOffer.New"
+		log = log.With(slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		offerID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || offerID < 1 {
+			response.Error(log, w, r, "invalid offer ID", "failed to parse offer ID", http.StatusBadRequest)
+			return
+		}
+
+		offer, err := responder.RespondCourierOffer(r.Context(), database.RespondCourierOfferParams{
+			Status:    dispatch.OfferDeclined,
+			ID:        int32(offerID),
+			CourierID: user.ID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "offer not found or expired", "no open offer", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "Can not decline offer", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		dispatcher.Answered(offer.OrderID)
+
+		log.Info("offer declined", slog.Int("order_id", int(offer.OrderID)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			OfferID: offer.ID,
+			OrderID: offer.OrderID,
+			Status:  offer.Status,
+		})
+	}
+}
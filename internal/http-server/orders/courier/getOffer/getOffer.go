@@ -0,0 +1,94 @@
+package getOffer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type Response struct {
+	OfferID           int32       `json:"offer_id" example:"4"`
+	OrderID           int32       `json:"order_id" example:"12"`
+	ExpiresAt         string      `json:"expires_at" example:"Mon, 02 Jan 2006 15:04:05 UTC"`
+	RestaurantName    string      `json:"restaurant_Name" example:"Mac"`
+	RestaurantAddress string      `json:"restaurant_Address" example:"123 address"`
+	DeliveryAddress   string      `json:"delivery_Address" example:"122 address"`
+	Reward            money.Money `json:"reward"`
+}
+
+type offerGetter interface {
+	GetOpenOfferForCourier(ctx context.Context, courierID int32) (database.CourierOffer, error)
+}
+
+type orderGetter interface {
+	GetFullOrderByID(ctx context.Context, id int32) ([]database.GetFullOrderByIDRow, error)
+}
+
+// Orders godoc
+// @Summary Текущее предложение заказа курьеру
+// @Description Возвращает заказ, который диспетчер предложил авторизованному курьеру. Предложение нужно принять или отклонить до expires_at, иначе оно уйдет следующему курьеру
+// @Tags Orders
+// @Produce json
+// @Success 200 {object} getOffer.Response "Предложение получено"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Нет активного предложения"
+// @Failure 500 {object} response.Response "Ошибка сервера"
+// @Router /orders/offer [get]
+// @Security BearerAuth
+func New(log *slog.Logger, offerGetter offerGetter, orderGetter orderGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http-server.orders.courier.getOffer"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		offer, err := offerGetter.GetOpenOfferForCourier(r.Context(), user.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "No offer", "no open offer", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		order, err := orderGetter.GetFullOrderByID(r.Context(), offer.OrderID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		if len(order) == 0 {
+			response.Error(log, w, r, "No offer", "offered order not found", http.StatusNotFound)
+			return
+		}
+
+		log.Info("offer sent", slog.Int("offer_id", int(offer.ID)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			OfferID:           offer.ID,
+			OrderID:           offer.OrderID,
+			ExpiresAt:         offer.ExpiresAt.Format(time.RFC1123),
+			RestaurantName:    order[0].RestaurantName,
+			RestaurantAddress: order[0].RestaurantAddress,
+			DeliveryAddress:   order[0].DeliveryAddress,
+			Reward:            money.New(order[0].Total, order[0].Currency).Percent(5),
+		})
+	}
+}
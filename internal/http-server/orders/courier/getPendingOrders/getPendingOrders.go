@@ -5,67 +5,107 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/orderStatus"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/ordersStruct"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/pagination"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
 )
 
 type ordersGetter interface {
-	GetFullPendingOrders(ctx context.Context) ([]database.GetFullPendingOrdersRow, error)
+	GetFullPendingOrders(ctx context.Context, arg database.GetFullPendingOrdersParams) ([]database.GetFullPendingOrdersRow, error)
 }
 
-type userGetter interface {
-	GetUserByID(ctx context.Context, id int32) (database.User, error)
+type optionsGetter interface {
+	GetOrderItemOptions(ctx context.Context, dollar_1 []int32) ([]database.OrderitemOption, error)
 }
 
 type Response struct {
-	PendingOrders []ordersStruct.OrderForCourier `json:"pending_orders"`
+	Items []ordersStruct.OrderForCourier `json:"items"`
+	pagination.Cursor
 }
 
 // Orders godoc
 // @Summary Получение всех доступных для доставки заказов
-// @Description Возвращает все заказы, которые еще не взяты
+// @Description Возвращает заказы, которые еще не взяты, новые первыми. Список отдается страницами: next_cursor передается в cursor для получения следующей страницы. Доступно только курьерам на линии (online)
 // @Tags Orders
 // @Accept json
 // @Produce json
-// @Success 200 {object} getPendingOrders.Response "Заказы успешно получен"
+// @Param limit query int false "Размер страницы (1-100, по умолчанию 20)"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param status query string false "Фильтр по статусу" Enums(accepted, preparing, ready_for_pickup)
+// @Param created_from query string false "Созданы не раньше (RFC 3339)"
+// @Param created_to query string false "Созданы раньше (RFC 3339)"
+// @Success 200 {object} getPendingOrders.Response "Заказы успешно получены"
 // @Failure 400 {object} response.Response "Некорректные данные"
 // @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
 // @Failure 404 {object} response.Response "Заказы не найдены"
 // @Failure 500 {object} response.Response "Ошибка сервера"
 // @Router /orders/pending [get]
 // @Security BearerAuth
-func New(log *slog.Logger, ordersGetter ordersGetter, userGetter userGetter) http.HandlerFunc {
+func New(log *slog.Logger, ordersGetter ordersGetter, optionsGetter optionsGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "http-server.orders.courier.getPendingOrders"
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())))
 
-		userID := r.Context().Value("userID").(int32)
-
-		userInfo, err := userGetter.GetUserByID(r.Context(), userID)
+		params, err := pagination.FromRequest(r)
 		if err != nil {
-			response.Error(log, w, r, "failed to get user", "No user", http.StatusInternalServerError)
+			response.Error(log, w, r, err.Error(), "invalid pagination", http.StatusBadRequest)
 			return
 		}
-
-		if userInfo.UserRole != "courier" {
-			response.Error(log, w, r, "access denied", "Wrong role", http.StatusForbidden)
+		status := pagination.StringParam(r, "status")
+		if status.Valid && !orderStatus.CanAssignCourier(orderStatus.Status(status.String)) {
+			response.Error(log, w, r, "status must be accepted, preparing or ready_for_pickup", "invalid status filter", http.StatusBadRequest)
+			return
+		}
+		createdFrom, err := pagination.TimeParam(r, "created_from")
+		if err != nil {
+			response.Error(log, w, r, err.Error(), "invalid created_from", http.StatusBadRequest)
+			return
+		}
+		createdTo, err := pagination.TimeParam(r, "created_to")
+		if err != nil {
+			response.Error(log, w, r, err.Error(), "invalid created_to", http.StatusBadRequest)
 			return
 		}
 
-		orders, err := ordersGetter.GetFullPendingOrders(r.Context())
+		orders, err := ordersGetter.GetFullPendingOrders(r.Context(), database.GetFullPendingOrdersParams{
+			Status:      status,
+			CreatedFrom: createdFrom,
+			CreatedTo:   createdTo,
+			AfterID:     params.After,
+			PageLimit:   params.QueryLimit(),
+		})
 		if err != nil {
 			response.Error(log, w, r, "failed to get pending orders", "no pending orders", http.StatusInternalServerError)
 			return
 		}
 
-		respOrders := ordersStruct.MakePendingOrders(orders)
+		orderIDs := make([]int32, 0, len(orders))
+		for _, row := range orders {
+			orderIDs = append(orderIDs, row.OrderID)
+		}
+		// one extra order was fetched to know whether there is a next page
+		pageRows, nextCursor := pagination.Cut(orderIDs, params.Limit)
+		orders, orderIDs = orders[:pageRows], orderIDs[:pageRows]
+
+		options, err := optionsGetter.GetOrderItemOptions(r.Context(), orderIDs)
+		if err != nil {
+			response.Error(log, w, r, "failed to get pending orders", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		respOrders := ordersStruct.MakePendingOrders(orders, ordersStruct.OptionsByItem(options))
+
 		render.Status(r, http.StatusOK)
 		render.JSON(w, r, Response{
-			respOrders,
+			Items:  respOrders,
+			Cursor: nextCursor,
 		})
 	}
 }
@@ -7,7 +7,13 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/orderStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/events"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/ordersStruct"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -15,44 +21,52 @@ import (
 )
 
 type Response struct {
-	RestaurantName    string  `json:"restaurant_Name" example:"Mac"`
-	RestaurantAddress string  `json:"restaurant_Address" example:"123 address"`
-	RestaurantPhone   string  `json:"restaurant_Phone" example:"89056666666"`
-	DeliveryAddress   string  `json:"delivery_Address" example:"1222 address"`
-	CourierName       string  `json:"courierName" example:"Bill"`
-	UserName          string  `json:"user_name" example:"Ivan"`
-	Status            string  `json:"status" example:"pending"`
-	CreatedAt         string  `json:"created_at" example:"2020-01-01T00:00:00+09:00"`
-	Items             []item  `json:"items"`
-	TotalPrice        float64 `json:"total_price" example:"300.0"`
+	RestaurantName    string      `json:"restaurant_Name" example:"Mac"`
+	RestaurantAddress string      `json:"restaurant_Address" example:"123 address"`
+	RestaurantPhone   string      `json:"restaurant_Phone" example:"89056666666"`
+	DeliveryAddress   string      `json:"delivery_Address" example:"1222 address"`
+	CourierName       string      `json:"courierName" example:"Bill"`
+	UserName          string      `json:"user_name" example:"Ivan"`
+	Status            string      `json:"status" example:"pending"`
+	CreatedAt         string      `json:"created_at" example:"2020-01-01T00:00:00+09:00"`
+	Items             []item      `json:"items"`
+	TotalPrice        money.Money `json:"total_price"`
 }
 type item struct {
-	ItemName  string  `json:"item_name" example:"Burger with cheese"`
-	ItemPrice float64 `json:"price" example:"100.0"`
-	Quantity  int32   `json:"quantity" example:"3"`
+	ItemName  string                `json:"item_name" example:"Burger with cheese"`
+	ItemPrice money.Money           `json:"price"`
+	Quantity  int32                 `json:"quantity" example:"3"`
+	Options   []ordersStruct.Option `json:"options"`
+	Total     money.Money           `json:"total"`
 }
 
-const StatusPending = "pending"
-
 type StatusUpdater interface {
 	UpdateCourierID(ctx context.Context, arg database.UpdateCourierIDParams) ([]database.UpdateCourierIDRow, error)
 }
 
-type StatusGetter interface {
-	GetOrderStatusByID(ctx context.Context, id int32) (string, error)
+type OrderGetter interface {
+	GetOrderByID(ctx context.Context, id int32) (database.Order, error)
 }
 
-type courierGetter interface {
-	GetUserByID(ctx context.Context, id int32) (database.User, error)
+type optionsGetter interface {
+	GetOrderItemOptions(ctx context.Context, dollar_1 []int32) ([]database.OrderitemOption, error)
 }
 
 type currentOrderGetter interface {
 	GetCurrentIDOrderForCourier(ctx context.Context, courierid sql.NullInt32) (int32, error)
 }
 
+type dispatcher interface {
+	Assigned(orderID, courierID int32, status string)
+}
+
+type publisher interface {
+	Publish(ctx context.Context, orderID int32, eventType, status string)
+}
+
 // Orders godoc
 // @Summary Взятие заказа курьером
-// @Description Назначает заказ на курьера
+// @Description Назначает заказ на курьера. Заказ должен быть принят рестораном (accepted, preparing или ready_for_pickup) и еще не иметь курьера. Курьер должен быть на линии (online)
 // @Tags Orders
 // @Accept json
 // @Produce json
@@ -60,32 +74,29 @@ type currentOrderGetter interface {
 // @Success 200 {object} orderAssign.Response "Заказ назначен"
 // @Failure 400 {object} response.Response "Некорректные данные"
 // @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
 // @Failure 404 {object} response.Response "Заказ не найден"
+// @Failure 409 {object} response.Response "Заказ уже взят другим курьером"
 // @Failure 500 {object} response.Response "Серверная Ошибка"
 // @Router /orders/{id}/assign [patch]
 // @Security BearerAuth
 func New(
 	log *slog.Logger,
-	getterStatus StatusGetter,
+	getterOrder OrderGetter,
 	updater StatusUpdater,
-	getterCourier courierGetter,
 	getterCurrent currentOrderGetter,
+	optionsGetter optionsGetter,
+	dispatcher dispatcher,
+	publisher publisher,
 ) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "http_server.orders.courier.ordersAssign.New"
 		log = log.With(slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())))
 
-		userID := r.Context().Value("userID").(int32)
-
-		courierInfo, err := getterCourier.GetUserByID(r.Context(), userID)
-		if err != nil {
-			response.Error(log, w, r, "Can not get courier", "Can not get courier", http.StatusInternalServerError)
-			return
-		}
-
-		if courierInfo.UserRole != "courier" {
-			response.Error(log, w, r, "Access denied", "wrong role", http.StatusForbidden)
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
 			return
 		}
 
@@ -107,19 +118,24 @@ func New(
 			return
 		}
 
-		orderInfo, err := getterStatus.GetOrderStatusByID(r.Context(), int32(parsedOrderID))
+		orderInfo, err := getterOrder.GetOrderByID(r.Context(), int32(parsedOrderID))
 		if err != nil {
 			response.Error(log, w, r, "No order", "cannot get order", http.StatusNotFound)
 			return
 		}
 
-		if orderInfo != StatusPending {
-			response.Error(log, w, r, "Order already accepted", "order already accepted", http.StatusForbidden)
+		if orderInfo.Courierid.Valid {
+			response.Error(log, w, r, "Order already taken by another courier", "order already has a courier", http.StatusConflict)
+			return
+		}
+
+		if !orderStatus.CanAssignCourier(orderStatus.Status(orderInfo.Status)) {
+			response.Error(log, w, r, "Order is not ready for couriers", "wrong order status", http.StatusForbidden)
 			return
 		}
 
 		_, err = getterCurrent.GetCurrentIDOrderForCourier(r.Context(), sql.NullInt32{
-			Int32: int32(courierInfo.ID),
+			Int32: user.ID,
 			Valid: true,
 		})
 
@@ -129,7 +145,7 @@ func New(
 		}
 
 		order, err := updater.UpdateCourierID(r.Context(), database.UpdateCourierIDParams{
-			Courierid: sql.NullInt32{Int32: courierInfo.ID, Valid: true},
+			Courierid: sql.NullInt32{Int32: user.ID, Valid: true},
 			ID:        int32(parsedOrderID),
 		})
 		if err != nil {
@@ -137,14 +153,22 @@ func New(
 			return
 		}
 
+		// the update is conditional, so a courier who lost the race gets no rows
 		if len(order) == 0 {
-			response.Error(log, w, r, "order not found", "no order updated", http.StatusNotFound)
+			response.Error(log, w, r, "Order already taken by another courier", "lost assignment race", http.StatusConflict)
 			return
 		}
 
+		// the order is already assigned here, so missing options are logged instead of failing the request
+		options, err := optionsGetter.GetOrderItemOptions(r.Context(), []int32{order[0].OrderID})
+		if err != nil {
+			log.Error("failed to get item options", sl.Err(err))
+		}
+		itemOptions := ordersStruct.OptionsByItem(options)
+
 		resp := Response{
-			RestaurantName:    order[0].RestaurantName.String,
-			RestaurantAddress: order[0].RestaurantAddress.String,
+			RestaurantName:    order[0].RestaurantName,
+			RestaurantAddress: order[0].RestaurantAddress,
 			RestaurantPhone:   order[0].RestaurantPhone,
 			DeliveryAddress:   order[0].DeliveryAddress,
 			CourierName:       order[0].CourierName.String,
@@ -152,17 +176,21 @@ func New(
 			Status:            order[0].Status,
 			CreatedAt:         order[0].CreatedAt.Time.Format(time.RFC1123),
 			Items:             []item{},
-			TotalPrice:        0,
+			TotalPrice:        money.New(order[0].Total, order[0].Currency),
 		}
 		for _, v := range order {
 			resp.Items = append(resp.Items, item{
 				ItemName:  v.MenuItemName,
-				ItemPrice: v.Price,
+				ItemPrice: money.New(v.Price, v.Currency),
 				Quantity:  v.Quanity,
+				Options:   ordersStruct.ItemOptions(itemOptions, v.OrderItemID),
+				Total:     ordersStruct.ItemTotal(v.Price, v.OptionsPrice, v.Quanity, v.Currency),
 			})
-			resp.TotalPrice += v.Price * float64(v.Quanity)
 		}
 
+		dispatcher.Assigned(order[0].OrderID, user.ID, order[0].Status)
+		publisher.Publish(r.Context(), order[0].OrderID, events.TypeCourierAssigned, order[0].Status)
+
 		log.Info("order assigned")
 		render.Status(r, http.StatusOK)
 		render.JSON(w, r, resp)
@@ -3,20 +3,24 @@ package orderDelivered
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/orderStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/events"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
 )
 
-type courierGetter interface {
-	GetUserByID(ctx context.Context, id int32) (database.User, error)
-}
+// errNotDelivering means the order left the delivering status between the check and the update.
+var errNotDelivering = errors.New("order is not being delivered")
 
-type statusUpdater interface {
-	UpdateOrderStatus(ctx context.Context, arg database.UpdateOrderStatusParams) error
+type orderDeliverer interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
 }
 
 type currentOrderGetter interface {
@@ -27,9 +31,13 @@ type Response struct {
 	OrderID int32 `json:"order_id"`
 }
 
+type publisher interface {
+	Publish(ctx context.Context, orderID int32, eventType, status string)
+}
+
 // Orders godoc
 // @Summary Изменение статуса заказа
-// @Description Изменяет статус доставляемого заказа
+// @Description Отмечает доставляемый заказ доставленным и увеличивает счетчик доставок курьера
 // @Tags Orders
 // @Accept json
 // @Produce json
@@ -39,18 +47,23 @@ type Response struct {
 // @Failure 401 {object} response.Response "Неавторизован"
 // @Failure 403 {object} response.Response "Доступ запрещен"
 // @Failure 404 {object} response.Response "Заказ не найден"
+// @Failure 409 {object} response.Response "Заказ еще не забран из ресторана"
 // @Failure 500 {object} response.Response "Серверная Ошибка"
 // @Router /orders/delivered [patch]
 // @Security BearerAuth
-func New(log *slog.Logger, getterCourier courierGetter, updater statusUpdater, getterOrder currentOrderGetter) http.HandlerFunc {
+func New(log *slog.Logger, deliverer orderDeliverer, getterOrder currentOrderGetter, publisher publisher) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "http_server.orders.courier.orderDelivered.New"
 		log = log.With(slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())))
 
-		userID := r.Context().Value("userID").(int32)
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
 
-		order, err := getterOrder.GetCurrentOrderForCourier(r.Context(), sql.NullInt32{Int32: userID, Valid: true})
+		order, err := getterOrder.GetCurrentOrderForCourier(r.Context(), sql.NullInt32{Int32: user.ID, Valid: true})
 		if err != nil {
 			response.Error(log, w, r, "Not found", "current order not found", http.StatusNotFound)
 			return
@@ -61,14 +74,35 @@ func New(log *slog.Logger, getterCourier courierGetter, updater statusUpdater, g
 			return
 		}
 
-		if err := updater.UpdateOrderStatus(r.Context(), database.UpdateOrderStatusParams{
-			Courierid: sql.NullInt32{Int32: userID, Valid: true},
-			ID:        order[0].OrderID,
-		}); err != nil {
-			response.Error(log, w, r, "Failed to update order", "cannot update", http.StatusInternalServerError)
+		if !orderStatus.CanTransition(orderStatus.Status(order[0].Status), orderStatus.Delivered) {
+			response.Error(log, w, r, "Order is not picked up yet", "wrong order status", http.StatusConflict)
 			return
 		}
 
+		err = deliverer.InTx(r.Context(), func(q *database.Queries) error {
+			updated, err := q.UpdateOrderStatus(r.Context(), database.UpdateOrderStatusParams{
+				Courierid: sql.NullInt32{Int32: user.ID, Valid: true},
+				ID:        order[0].OrderID,
+			})
+			if err != nil {
+				return err
+			}
+			if updated == 0 {
+				return errNotDelivering
+			}
+			return q.IncrementCourierOrderCount(r.Context(), user.ID)
+		})
+		if errors.Is(err, errNotDelivering) {
+			response.Error(log, w, r, "Order is not picked up yet", "status changed concurrently", http.StatusConflict)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "Failed to update order", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		publisher.Publish(r.Context(), order[0].OrderID, events.TypeStatusChanged, orderStatus.Delivered.String())
+
 		render.Status(r, http.StatusOK)
 		render.JSON(w, r, Response{
 			order[0].OrderID,
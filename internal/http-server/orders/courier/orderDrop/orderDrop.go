@@ -0,0 +1,171 @@
+package orderDrop
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/orderStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/events"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// errNotAssigned means the order was picked up or reassigned between the check and the update.
+var errNotAssigned = errors.New("order is not assigned to the courier")
+
+type Request struct {
+	Reason string `json:"reason" validate:"required,max=500" example:"bike broke down"`
+}
+
+type Response struct {
+	OrderID int32  `json:"order_id" example:"12"`
+	Status  string `json:"status" example:"preparing"`
+}
+
+type orderGetter interface {
+	GetOrderByID(ctx context.Context, id int32) (database.Order, error)
+}
+
+// courierDropper unassigns the courier and records the reason in one transaction, so an order never
+// loses its courier without a record.
+type courierDropper interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
+}
+
+type dispatcher interface {
+	Dispatch(orderID int32)
+}
+
+type publisher interface {
+	Publish(ctx context.Context, orderID int32, eventType, status string)
+}
+
+// Orders godoc
+// @Summary Отказ курьера от заказа
+// @Description Курьер отказывается от назначенного заказа до того, как забрал его, и заказ возвращается в общий список. Готовый заказ сразу предлагается следующему курьеру
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "ID Заказа"
+// @Param request body orderDrop.Request true "Причина отказа"
+// @Success 200 {object} orderDrop.Response "Курьер снят с заказа"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Заказ не найден"
+// @Failure 409 {object} response.Response "Заказ уже забран"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /orders/{id}/drop [patch]
+// @Security BearerAuth
+func New(
+	log *slog.Logger,
+	getter orderGetter,
+	dropper courierDropper,
+	dispatcher dispatcher,
+	publisher publisher,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http_server.orders.courier.orderDrop.New"
+		log = log.With(slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		orderID := chi.URLParam(r, "id")
+		if orderID == "" {
+			response.Error(log, w, r, "Missing orderID", "no order id provided", http.StatusBadRequest)
+			return
+		}
+
+		parsedOrderID, err := strconv.ParseInt(orderID, 10, 32)
+		if err != nil || parsedOrderID < 1 {
+			response.Error(log, w, r, "Wrong orderID", "Invalid orderID", http.StatusBadRequest)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		order, err := getter.GetOrderByID(r.Context(), int32(parsedOrderID))
+		if err != nil {
+			response.Error(log, w, r, "No order", "cannot get order", http.StatusNotFound)
+			return
+		}
+
+		if !order.Courierid.Valid || order.Courierid.Int32 != user.ID {
+			response.Error(log, w, r, "Access denied", "order assigned to another courier", http.StatusForbidden)
+			return
+		}
+
+		if !orderStatus.CanDropCourier(orderStatus.Status(order.Status)) {
+			response.Error(log, w, r, "Order is already picked up", "wrong order status", http.StatusConflict)
+			return
+		}
+
+		err = dropper.InTx(r.Context(), func(q *database.Queries) error {
+			dropped, err := q.DropCourier(r.Context(), database.DropCourierParams{
+				ID:        order.ID,
+				Courierid: sql.NullInt32{Int32: user.ID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			if dropped == 0 {
+				return errNotAssigned
+			}
+			_, err = q.CreateOrderCancellation(r.Context(), database.CreateOrderCancellationParams{
+				OrderID:  order.ID,
+				UserID:   user.ID,
+				UserRole: user.Role,
+				Reason:   sql.NullString{String: req.Reason, Valid: true},
+			})
+			return err
+		})
+		if errors.Is(err, errNotAssigned) {
+			response.Error(log, w, r, "Order is already picked up", "order changed concurrently", http.StatusConflict)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "Can not drop order", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		publisher.Publish(r.Context(), order.ID, events.TypeCourierUnassigned, order.Status)
+		// an order still being cooked is dispatched once the restaurant marks it ready
+		if orderStatus.Status(order.Status) == orderStatus.ReadyForPickup {
+			dispatcher.Dispatch(order.ID)
+		}
+
+		log.Info("courier dropped order")
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			OrderID: order.ID,
+			Status:  order.Status,
+		})
+	}
+}
@@ -6,7 +6,12 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/orderStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/ordersStruct"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -17,22 +22,39 @@ type orderGetter interface {
 	GetFullOrderByID(ctx context.Context, id int32) ([]database.GetFullOrderByIDRow, error)
 }
 
+type optionsGetter interface {
+	GetOrderItemOptions(ctx context.Context, dollar_1 []int32) ([]database.OrderitemOption, error)
+}
+
+type cancellationsGetter interface {
+	GetCancellationsByOrderID(ctx context.Context, orderID int32) ([]database.OrderCancellation, error)
+}
+
 type Response struct {
-	RestaurantName    string  `json:"restaurant_Name" example:"Mac"`
-	RestaurantAddress string  `json:"restaurant_Address" example:"123 address"`
-	RestaurantPhone   string  `json:"restaurant_Phone" example:"89055463333"`
-	DeliveryAddress   string  `json:"delivery_Address" example:"1222 address"`
-	CourierName       string  `json:"courierName" example:"John"`
-	UserName          string  `json:"user_name" example:"Bill"`
-	Status            string  `json:"status" example:"pending"`
-	CreatedAt         string  `json:"created_at" example:"2020-09-20T14:14:15+09:00"`
-	Items             []item  `json:"items"`
-	TotalPrice        float64 `json:"total_price" example:"300.0"`
+	RestaurantName    string        `json:"restaurant_Name" example:"Mac"`
+	RestaurantAddress string        `json:"restaurant_Address" example:"123 address"`
+	RestaurantPhone   string        `json:"restaurant_Phone" example:"89055463333"`
+	DeliveryAddress   string        `json:"delivery_Address" example:"1222 address"`
+	CourierName       string        `json:"courierName" example:"John"`
+	UserName          string        `json:"user_name" example:"Bill"`
+	Status            string        `json:"status" example:"pending"`
+	CreatedAt         string        `json:"created_at" example:"2020-09-20T14:14:15+09:00"`
+	Items             []item        `json:"items"`
+	TotalPrice        money.Money   `json:"total_price"`
+	Cancellation      *cancellation `json:"cancellation,omitempty"`
 }
+type cancellation struct {
+	By        string `json:"by" example:"restaurant"`
+	Reason    string `json:"reason,omitempty" example:"out of buns"`
+	CreatedAt string `json:"created_at" example:"2020-09-20T14:14:15+09:00"`
+}
+
 type item struct {
-	ItemName  string  `json:"item_name" example:"burger"`
-	ItemPrice float64 `json:"price" example:"60.0"`
-	Quantity  int32   `json:"quantity" example:"5"`
+	ItemName  string                `json:"item_name" example:"burger"`
+	ItemPrice money.Money           `json:"price"`
+	Quantity  int32                 `json:"quantity" example:"5"`
+	Options   []ordersStruct.Option `json:"options"`
+	Total     money.Money           `json:"total"`
 }
 
 // orders godoc
@@ -49,7 +71,7 @@ type item struct {
 // @Failure 500 {object} response.Response "Ошибка сервера"
 // @Router /orders/{id} [get]
 // @Security BearerAuth
-func New(log *slog.Logger, getter orderGetter) http.HandlerFunc {
+func New(log *slog.Logger, getter orderGetter, optionsGetter optionsGetter, cancellations cancellationsGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "http_server.orders.getOrderByID.New"
 
@@ -57,7 +79,11 @@ func New(log *slog.Logger, getter orderGetter) http.HandlerFunc {
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())))
 
-		userID := r.Context().Value("userID").(int32)
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
 
 		orderID := chi.URLParam(r, "id")
 		if orderID == "" {
@@ -81,14 +107,21 @@ func New(log *slog.Logger, getter orderGetter) http.HandlerFunc {
 			return
 		}
 
-		if order[0].CustomerID != userID {
+		if order[0].CustomerID != user.ID {
 			response.Error(log, w, r, "Access denied", "not matching ids", http.StatusForbidden)
 			return
 		}
 
+		options, err := optionsGetter.GetOrderItemOptions(r.Context(), []int32{order[0].OrderID})
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		itemOptions := ordersStruct.OptionsByItem(options)
+
 		resp := Response{
-			RestaurantName:    order[0].RestaurantName.String,
-			RestaurantAddress: order[0].RestaurantAddress.String,
+			RestaurantName:    order[0].RestaurantName,
+			RestaurantAddress: order[0].RestaurantAddress,
 			RestaurantPhone:   order[0].RestaurantPhone,
 			DeliveryAddress:   order[0].DeliveryAddress,
 			CourierName:       order[0].CourierName.String,
@@ -96,15 +129,31 @@ func New(log *slog.Logger, getter orderGetter) http.HandlerFunc {
 			Status:            order[0].Status,
 			CreatedAt:         order[0].CreatedAt.Time.Format(time.RFC3339),
 			Items:             []item{},
-			TotalPrice:        0,
+			TotalPrice:        money.New(order[0].Total, order[0].Currency),
 		}
 		for _, v := range order {
 			resp.Items = append(resp.Items, item{
 				ItemName:  v.MenuItemName,
-				ItemPrice: v.Price,
+				ItemPrice: money.New(v.Price, v.Currency),
 				Quantity:  v.Quanity,
+				Options:   ordersStruct.ItemOptions(itemOptions, v.OrderItemID),
+				Total:     ordersStruct.ItemTotal(v.Price, v.OptionsPrice, v.Quanity, v.Currency),
 			})
-			resp.TotalPrice += v.Price * float64(v.Quanity)
+		}
+
+		status := orderStatus.Status(resp.Status)
+		if status == orderStatus.Cancelled || status == orderStatus.Rejected {
+			records, err := cancellations.GetCancellationsByOrderID(r.Context(), order[0].OrderID)
+			if err != nil {
+				log.Error("failed to get cancellations", sl.Err(err))
+			} else if len(records) > 0 {
+				last := records[len(records)-1]
+				resp.Cancellation = &cancellation{
+					By:        last.UserRole,
+					Reason:    last.Reason.String,
+					CreatedAt: last.CreatedAt.Format(time.RFC3339),
+				}
+			}
 		}
 
 		log.Info("got order")
@@ -5,62 +5,115 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/orderStatus"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/ordersStruct"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/pagination"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
 )
 
 type OrderGetter interface {
-	GetFullOrdersByUserID(ctx context.Context, customerid int32) ([]database.GetFullOrdersByUserIDRow, error)
+	GetFullOrdersByUserID(ctx context.Context, arg database.GetFullOrdersByUserIDParams) ([]database.GetFullOrdersByUserIDRow, error)
+}
+
+type optionsGetter interface {
+	GetOrderItemOptions(ctx context.Context, dollar_1 []int32) ([]database.OrderitemOption, error)
 }
 
 type Response struct {
-	Orders []ordersStruct.Order `json:"ordersStruct"`
+	Items []ordersStruct.Order `json:"items"`
+	pagination.Cursor
 }
 
 // orders godoc
 // @Summary Получение заказов по JWT
-// @Description Возвращает полную информацию по заказам для авторизованного пользователя
+// @Description Возвращает полную информацию по заказам для авторизованного пользователя, новые первыми. Список отдается страницами: next_cursor передается в cursor для получения следующей страницы
 // @Tags Orders
 // @Accept json
 // @Produce json
-// @Success 200 {object} getOrdersForUser.Response "Заказ успешно получен"
-// @Success 204 "Нет заказов"
+// @Param limit query int false "Размер страницы (1-100, по умолчанию 20)"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param status query string false "Фильтр по статусу" Enums(pending, accepted, preparing, ready_for_pickup, delivering, delivered, cancelled, rejected)
+// @Param created_from query string false "Созданы не раньше (RFC 3339)"
+// @Param created_to query string false "Созданы раньше (RFC 3339)"
+// @Success 200 {object} getOrdersForUser.Response "Заказы успешно получены"
 // @Failure 400 {object} response.Response "Неккоректные данные"
 // @Failure 401 {object} response.Response "Не авторизован"
 // @Failure 403 {object} response.Response "Доступ Запрещен"
 // @Failure 500 {object} response.Response "Ошибка сервера"
 // @Router /orders [get]
 // @Security BearerAuth
-func New(log *slog.Logger, getter OrderGetter) http.HandlerFunc {
+func New(log *slog.Logger, getter OrderGetter, optionsGetter optionsGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "httpserver.ordersStruct.getOrdersForUser.New"
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())))
 
-		userID := r.Context().Value("userID").(int32)
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		params, err := pagination.FromRequest(r)
+		if err != nil {
+			response.Error(log, w, r, err.Error(), "invalid pagination", http.StatusBadRequest)
+			return
+		}
+		status := pagination.StringParam(r, "status")
+		if status.Valid && !orderStatus.Status(status.String).IsValid() {
+			response.Error(log, w, r, "unknown status", "invalid status filter", http.StatusBadRequest)
+			return
+		}
+		createdFrom, err := pagination.TimeParam(r, "created_from")
+		if err != nil {
+			response.Error(log, w, r, err.Error(), "invalid created_from", http.StatusBadRequest)
+			return
+		}
+		createdTo, err := pagination.TimeParam(r, "created_to")
+		if err != nil {
+			response.Error(log, w, r, err.Error(), "invalid created_to", http.StatusBadRequest)
+			return
+		}
 
-		ordersInfo, err := getter.GetFullOrdersByUserID(r.Context(), int32(userID))
+		ordersInfo, err := getter.GetFullOrdersByUserID(r.Context(), database.GetFullOrdersByUserIDParams{
+			Customerid:  user.ID,
+			Status:      status,
+			CreatedFrom: createdFrom,
+			CreatedTo:   createdTo,
+			AfterID:     params.After,
+			PageLimit:   params.QueryLimit(),
+		})
 		if err != nil {
 			response.Error(log, w, r, "No orders", "failed to get the ordersStruct", http.StatusNotFound)
 			return
 		}
 
-		orders := ordersStruct.MakeOrders(ordersInfo)
-		if len(orders) == 0 {
-			log.Info("No orders found")
-			render.Status(r, http.StatusNoContent)
-			render.JSON(w, r, Response{
-				[]ordersStruct.Order{},
-			})
+		orderIDs := make([]int32, 0, len(ordersInfo))
+		for _, row := range ordersInfo {
+			orderIDs = append(orderIDs, row.OrderID)
 		}
+		// one extra order was fetched to know whether there is a next page
+		pageRows, nextCursor := pagination.Cut(orderIDs, params.Limit)
+		ordersInfo, orderIDs = ordersInfo[:pageRows], orderIDs[:pageRows]
+
+		options, err := optionsGetter.GetOrderItemOptions(r.Context(), orderIDs)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		orders := ordersStruct.MakeOrders(ordersInfo, ordersStruct.OptionsByItem(options))
 
-		log.Info("got orders")
+		log.Info("got orders", slog.Int("count", len(orders)))
 		render.Status(r, http.StatusOK)
 		render.JSON(w, r, Response{
-			orders,
+			Items:  orders,
+			Cursor: nextCursor,
 		})
 	}
 }
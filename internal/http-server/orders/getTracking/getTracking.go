@@ -0,0 +1,150 @@
+package getTracking
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/orderStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type Response struct {
+	OrderID int32  `json:"order_id" example:"12"`
+	Status  string `json:"status" example:"delivering"`
+	// Location is null until the courier sends the first fix.
+	Location *Location `json:"location"`
+	Trail    []Point   `json:"trail"`
+}
+
+type Location struct {
+	geo.Point
+	Accuracy  *float64 `json:"accuracy,omitempty" example:"12.5"`
+	UpdatedAt string   `json:"updated_at" example:"Mon, 02 Jan 2006 15:04:05 UTC"`
+}
+
+type Point struct {
+	geo.Point
+	RecordedAt string `json:"recorded_at" example:"Mon, 02 Jan 2006 15:04:05 UTC"`
+}
+
+type orderGetter interface {
+	GetOrderByID(ctx context.Context, id int32) (database.Order, error)
+}
+
+type trackingGetter interface {
+	GetCourierLocation(ctx context.Context, courierID int32) (database.CourierLocation, error)
+	GetTrackingPoints(ctx context.Context, orderID int32) ([]database.OrderTrackingPoint, error)
+}
+
+// Orders godoc
+// @Summary Отслеживание курьера
+// @Description Возвращает текущую позицию курьера и трек доставки. Доступно только заказчику, пока заказ назначен на курьера и не завершен
+// @Tags Orders
+// @Produce json
+// @Param id path int true "ID Заказа"
+// @Success 200 {object} getTracking.Response "Позиция курьера"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Заказ не найден или курьер еще не назначен"
+// @Failure 409 {object} response.Response "Заказ уже завершен"
+// @Failure 500 {object} response.Response "Ошибка сервера"
+// @Router /orders/{id}/tracking [get]
+// @Security BearerAuth
+func New(log *slog.Logger, getter orderGetter, tracking trackingGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http_server.orders.getTracking.New"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		orderID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || orderID < 1 {
+			response.Error(log, w, r, "Wrong orderID", "Invalid orderID", http.StatusBadRequest)
+			return
+		}
+
+		order, err := getter.GetOrderByID(r.Context(), int32(orderID))
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "No order", "order not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		if order.Customerid != user.ID {
+			response.Error(log, w, r, "Access denied", "not matching ids", http.StatusForbidden)
+			return
+		}
+
+		// the courier position is private once the delivery is over
+		if orderStatus.Status(order.Status).IsFinal() {
+			response.Error(log, w, r, "Order is already finished", "final order status", http.StatusConflict)
+			return
+		}
+		if !order.Courierid.Valid {
+			response.Error(log, w, r, "Courier is not assigned yet", "no courier", http.StatusNotFound)
+			return
+		}
+
+		resp := Response{
+			OrderID: order.ID,
+			Status:  order.Status,
+			Trail:   []Point{},
+		}
+
+		location, err := tracking.GetCourierLocation(r.Context(), order.Courierid.Int32)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		if err == nil {
+			resp.Location = &Location{
+				Point:     geo.Point{Lat: location.Lat, Lng: location.Lng},
+				UpdatedAt: location.UpdatedAt.Format(time.RFC1123),
+			}
+			if location.Accuracy.Valid {
+				resp.Location.Accuracy = &location.Accuracy.Float64
+			}
+		}
+
+		points, err := tracking.GetTrackingPoints(r.Context(), order.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		for _, p := range points {
+			// a reassigned order keeps the trail of the previous courier, only the current one is shown
+			if p.CourierID != order.Courierid.Int32 {
+				continue
+			}
+			resp.Trail = append(resp.Trail, Point{
+				Point:      geo.Point{Lat: p.Lat, Lng: p.Lng},
+				RecordedAt: p.RecordedAt.Format(time.RFC1123),
+			})
+		}
+
+		log.Info("tracking sent")
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, resp)
+	}
+}
@@ -0,0 +1,116 @@
+package orderEvents
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/events"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/sse"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type orderGetter interface {
+	GetOrderByID(ctx context.Context, id int32) (database.Order, error)
+}
+
+type eventsGetter interface {
+	GetOrderEventsAfter(ctx context.Context, arg database.GetOrderEventsAfterParams) ([]database.OrderEvent, error)
+}
+
+type subscriber interface {
+	Subscribe(filter events.Filter) *events.Subscription
+	Unsubscribe(sub *events.Subscription)
+}
+
+// Orders godoc
+// @Summary Поток событий заказа (SSE)
+// @Description Server-Sent Events с изменениями заказа для заказчика и назначенного курьера. Сначала отдается история событий, затем новые события. При переподключении заголовок Last-Event-ID продолжает поток после указанного события. Раз в 15 секунд приходит комментарий-heartbeat
+// @Tags Orders
+// @Produce text/event-stream
+// @Param id path int true "ID Заказа"
+// @Param Last-Event-ID header int false "ID последнего полученного события"
+// @Success 200 {object} events.Event "Поток событий, в data каждого события JSON"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Заказ не найден"
+// @Failure 500 {object} response.Response "Ошибка сервера"
+// @Router /orders/{id}/events [get]
+// @Security BearerAuth
+func New(log *slog.Logger, getter orderGetter, eventsGetter eventsGetter, bus subscriber) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http_server.orders.orderEvents.New"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		orderID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || orderID < 1 {
+			response.Error(log, w, r, "Wrong orderID", "Invalid orderID", http.StatusBadRequest)
+			return
+		}
+
+		afterID, _, err := sse.LastEventID(r)
+		if err != nil {
+			response.Error(log, w, r, err.Error(), "invalid Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+
+		order, err := getter.GetOrderByID(r.Context(), int32(orderID))
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "No order", "order not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		isCourier := order.Courierid.Valid && order.Courierid.Int32 == user.ID
+		if order.Customerid != user.ID && !isCourier {
+			response.Error(log, w, r, "Access denied", "not matching ids", http.StatusForbidden)
+			return
+		}
+
+		sub := bus.Subscribe(events.Filter{OrderID: order.ID})
+		defer bus.Unsubscribe(sub)
+
+		// without Last-Event-ID the whole history of the order is sent
+		replay := func(ctx context.Context, afterID int64, limit int32) ([]events.Event, error) {
+			rows, err := eventsGetter.GetOrderEventsAfter(ctx, database.GetOrderEventsAfterParams{
+				OrderID:   order.ID,
+				AfterID:   afterID,
+				PageLimit: limit,
+			})
+			if err != nil {
+				return nil, err
+			}
+			res := make([]events.Event, 0, len(rows))
+			for _, row := range rows {
+				res = append(res, events.FromRow(row))
+			}
+			return res, nil
+		}
+
+		log.Info("order event stream opened", slog.Int("order_id", int(order.ID)))
+		if err := sse.Serve(w, r, log, sub, afterID, replay); err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		log.Info("order event stream closed")
+	}
+}
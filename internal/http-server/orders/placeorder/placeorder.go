@@ -2,83 +2,156 @@ package placeorder
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
 	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/deliveryZone"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/menuOptions"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+	"github.com/yourgfslove/GodFoodApi/internal/events"
+	"github.com/yourgfslove/GodFoodApi/internal/geocode"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/addressStruct"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/ordersStruct"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/restaurantStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
 	"slices"
+	"strings"
 	"time"
 )
 
-type orderCreater interface {
-	CreateOrder(ctx context.Context, arg database.CreateOrderParams) (database.Order, error)
-}
+var (
+	errAddressConflict     = errors.New("pass either address_id or address, not both")
+	errNoAddress           = errors.New("no user address")
+	errLocationNoAddress   = errors.New("location is only accepted together with address")
+	errOutsideZones        = errors.New("delivery address is outside the delivery area")
+	errApproximateLocation = errors.New("the restaurant delivers by zones: pin the location of the delivery address")
+	errBelowMinimum        = errors.New("order is below the minimum of every delivery zone covering the address")
+	errNegativePrice       = errors.New("options bring the item price below zero")
+)
 
-type menuItemsAdderNGetter interface {
-	AddItems(ctx context.Context, arg database.AddItemsParams) ([]database.Orderitem, error)
+// orderPlacer runs the order and its items in one transaction so a failed insert never leaves an empty order behind.
+type orderPlacer interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
 }
 
 type availableItemsGetter interface {
 	GetAvailableIDByRestaurantID(ctx context.Context, restaurantID int32) ([]int32, error)
 }
 
+type optionsGetter interface {
+	GetOptionGroupsByMenuItemIDs(ctx context.Context, dollar_1 []int32) ([]database.OptionGroup, error)
+	GetOptionsByMenuItemIDs(ctx context.Context, dollar_1 []int32) ([]database.Option, error)
+}
+
+type restaurantGetter interface {
+	GetRestaurantByID(ctx context.Context, id int32) (database.Restaurant, error)
+	GetRestaurantHours(ctx context.Context, restaurantID int32) ([]database.RestaurantHour, error)
+	GetRestaurantHolidays(ctx context.Context, restaurantID int32) ([]database.RestaurantHoliday, error)
+}
+
 type userGetter interface {
 	GetUserByID(ctx context.Context, id int32) (database.User, error)
 }
 
+type addressGetter interface {
+	GetAddressByID(ctx context.Context, arg database.GetAddressByIDParams) (database.Address, error)
+	GetDefaultAddress(ctx context.Context, userID int32) (database.Address, error)
+}
+
+type zoneGetter interface {
+	GetDeliveryZonesForPoint(ctx context.Context, arg database.GetDeliveryZonesForPointParams) ([]database.DeliveryZone, error)
+	CountDeliveryZones(ctx context.Context, restaurantID int32) (int64, error)
+}
+
 type Request struct {
 	RestaurantID int32  `json:"restaurant_id" example:"14"`
+	AddressID    int32  `json:"address_id,omitempty" example:"3"`
 	Address      string `json:"address,omitempty" example:"123 address"`
-	Items        []struct {
-		MenuitemID int32 `json:"menuitem_id" example:"6"`
-		Quantity   int32 `json:"quantity" example:"5"`
-	} `json:"items"`
+	// Location pins the free text address on the map instead of geocoding it.
+	Location *geo.Point `json:"location,omitempty"`
+	Items    []struct {
+		MenuitemID int32   `json:"menuitem_id" example:"6"`
+		Quantity   int32   `json:"quantity" validate:"gt=0,lte=99" example:"5"`
+		OptionIDs  []int32 `json:"option_ids,omitempty"`
+	} `json:"items" validate:"dive"`
 }
 
 type Response struct {
-	OrderID      int32  `json:"order_id" example:"12"`
-	RestaurantID int32  `json:"restaurant_id" example:"14"`
-	Status       string `json:"status" example:"pending"`
-	CreatedAt    string `json:"created_at" example:"Tue, 17 Jun 2025 00:25:16 +0000"`
-	Address      string `json:"user_address" example:"123 address"`
-	Items        []struct {
-		MenuitemID int32 `json:"menuitem_id" example:"6"`
-		Quantity   int32 `json:"quantity" example:"5"`
-	} `json:"items"`
+	OrderID      int32       `json:"order_id" example:"12"`
+	RestaurantID int32       `json:"restaurant_id" example:"14"`
+	Status       string      `json:"status" example:"pending"`
+	CreatedAt    string      `json:"created_at" example:"Tue, 17 Jun 2025 00:25:16 +0000"`
+	Address      string      `json:"user_address" example:"123 address"`
+	AddressID    int32       `json:"address_id,omitempty" example:"3"`
+	Location     *geo.Point  `json:"delivery_location,omitempty"`
+	DeliveryFee  money.Money `json:"delivery_fee"`
+	Total        money.Money `json:"total_price"`
+	Items        []item      `json:"items"`
+}
+
+type item struct {
+	MenuitemID int32                 `json:"menuitem_id" example:"6"`
+	ItemName   string                `json:"item_name" example:"burger"`
+	ItemPrice  money.Money           `json:"price"`
+	Quantity   int32                 `json:"quantity" example:"5"`
+	Options    []ordersStruct.Option `json:"options"`
+	Total      money.Money           `json:"total"`
+}
+
+type publisher interface {
+	Publish(ctx context.Context, orderID int32, eventType, status string)
 }
 
 // Orders godoc
 // @Summary Создание нового заказа авторизованным пользователем
-// @Description Создает новый заказ
+// @Description Создает новый заказ. Для позиций с группами опций выбранные опции передаются в option_ids и проверяются по ограничениям групп. Количество каждой позиции от 1 до 99. Адрес доставки задается через address_id из адресной книги или строкой address, без них используется адрес по умолчанию. Для строки address можно передать точку на карте в location, иначе адрес геокодируется. Если геокодер не знает реальных адресов (провайдер stub), ресторан с зонами доставки принимает только заказы на адрес с точкой location, указанной сейчас или при сохранении адреса. Если у ресторана есть зоны доставки, адрес должен попасть хотя бы в одну из них: выбирается зона с самой дешевой доставкой среди тех, чью минимальную сумму заказа покрывает сумма позиций, ее стоимость доставки добавляется к total_price. Если сумма позиций меньше минимальной суммы всех подходящих зон, заказ отклоняется
 // @Tags Orders
 // @Accept json
 // @Produce json
 // @Param request body placeorder.Request true "Данные для добавления"
-// @Success 200 {object} placeorder.Response "Новый заказ успешно создан "
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор запроса с тем же ключом вернет сохраненный ответ"
+// @Success 201 {object} placeorder.Response "Новый заказ успешно создан "
 // @Failure 400 {object} response.Response "Некорректные данные"
 // @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Ресторан или адрес не найден"
+// @Failure 409 {object} response.Response "Ресторан закрыт, адрес вне зон доставки, сумма меньше минимальной, цена позиции с опциями ниже нуля, цены в разных валютах или ключ идемпотентности использован с другим запросом"
 // @Failure 500 {object} response.Response "Серверная Ошибка"
 // @Router /orders [post]
 // @Security BearerAuth
 func New(
 	log *slog.Logger,
-	creater orderCreater,
+	placer orderPlacer,
 	userGetter userGetter,
-	adder menuItemsAdderNGetter,
+	addressGetter addressGetter,
+	geocoder geocode.Geocoder,
 	availableGetter availableItemsGetter,
+	restaurantGetter restaurantGetter,
+	optionsGetter optionsGetter,
+	zoneGetter zoneGetter,
+	publisher publisher,
 ) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "http-server.ordersStruct.placeorder"
 		log = log.With(slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())))
 
-		userID := r.Context().Value("userID").(int32)
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
 
-		userInfo, err := userGetter.GetUserByID(r.Context(), int32(userID))
+		userInfo, err := userGetter.GetUserByID(r.Context(), user.ID)
 		if err != nil {
 			response.Error(log, w, r, "failed to get user", sl.Err(err).String(), http.StatusInternalServerError)
 			return
@@ -90,11 +163,57 @@ func New(
 			return
 		}
 
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
 		if len(req.Items) == 0 {
 			response.Error(log, w, r, "Not Found", "not items found", http.StatusNotFound)
 			return
 		}
 
+		restaurant, err := restaurantGetter.GetRestaurantByID(r.Context(), req.RestaurantID)
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "restaurant not found", "no restaurant by following id", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		hours, err := restaurantGetter.GetRestaurantHours(r.Context(), restaurant.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		holidays, err := restaurantGetter.GetRestaurantHolidays(r.Context(), restaurant.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		status, err := restaurantStatus.Make(restaurant, hours, holidays, time.Now())
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		if status.Paused {
+			response.Error(log, w, r, "restaurant is not accepting orders right now", "restaurant paused", http.StatusConflict)
+			return
+		}
+		if !status.IsOpen {
+			msg := "restaurant is closed"
+			if status.NextOpeningAt != "" {
+				msg = fmt.Sprintf("restaurant is closed until %s", status.NextOpeningAt)
+			}
+			response.Error(log, w, r, msg, "restaurant closed", http.StatusConflict)
+			return
+		}
+
 		availableItems, err := availableGetter.GetAvailableIDByRestaurantID(r.Context(), req.RestaurantID)
 		if err != nil {
 			response.Error(log, w, r, "failed to get available items", "failed to find available items", http.StatusInternalServerError)
@@ -109,51 +228,327 @@ func New(
 				return
 			}
 		}
-		address := req.Address
-		if address == "" {
-			if !userInfo.Address.Valid {
-				response.Error(log, w, r, "no user address", "No address", http.StatusBadRequest)
+
+		menuItemIDs := make([]int32, 0, len(req.Items))
+		for _, item := range req.Items {
+			menuItemIDs = append(menuItemIDs, item.MenuitemID)
+		}
+		groups, err := optionsGetter.GetOptionGroupsByMenuItemIDs(r.Context(), menuItemIDs)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		options, err := optionsGetter.GetOptionsByMenuItemIDs(r.Context(), menuItemIDs)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		itemGroups := groupsByItem(groups, options)
+		// options price is per unit, the option snapshot itself is copied from the db inside the transaction
+		optionsPrice := make([]int64, len(req.Items))
+		for i, item := range req.Items {
+			optionsPrice[i], err = menuOptions.Select(itemGroups[item.MenuitemID], item.OptionIDs)
+			if err != nil {
+				response.Error(log, w, r,
+					fmt.Sprintf("item %v: %s", item.MenuitemID, err.Error()),
+					sl.Err(err).String(),
+					http.StatusBadRequest)
 				return
 			}
-			address = userInfo.Address.String
 		}
 
-		order, err := creater.CreateOrder(r.Context(), database.CreateOrderParams{
-			Customerid:   userInfo.ID,
-			Restaurantid: req.RestaurantID,
-			Address:      address,
-		})
+		delivery, err := deliveryAddress(r.Context(), req, userInfo, addressGetter, geocoder)
+		if errors.Is(err, errAddressConflict) || errors.Is(err, errNoAddress) || errors.Is(err, errLocationNoAddress) ||
+			errors.Is(err, geocode.ErrNotFound) || errors.Is(err, geo.ErrInvalidPoint) {
+			response.Error(log, w, r, err.Error(), sl.Err(err).String(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "address not found", "no address of user", http.StatusNotFound)
+			return
+		}
 		if err != nil {
-			response.Error(log, w, r, "something went wrong", "failed to create order", http.StatusInternalServerError)
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
 			return
 		}
-		orderIDs := make([]int32, len(req.Items))
-		itemIDs := make([]int32, len(req.Items))
-		quantity := make([]int32, len(req.Items))
-		for i, item := range req.Items {
-			orderIDs[i] = order.ID
-			itemIDs[i] = item.MenuitemID
-			quantity[i] = item.Quantity
-		}
-		_, err = adder.AddItems(r.Context(), database.AddItemsParams{
-			Column1: orderIDs,
-			Column2: itemIDs,
-			Column3: quantity,
+
+		zones, err := coveringZones(r.Context(), zoneGetter, restaurant.ID, delivery)
+		if errors.Is(err, errApproximateLocation) {
+			response.Error(log, w, r, err.Error(), "approximate location for zoned restaurant", http.StatusBadRequest)
+			return
+		}
+		if errors.Is(err, errOutsideZones) {
+			response.Error(log, w, r, err.Error(), "address outside delivery zones", http.StatusConflict)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		var order database.Order
+		var items []database.Orderitem
+		var itemOptions map[int32][]ordersStruct.Option
+		err = placer.InTx(r.Context(), func(q *database.Queries) error {
+			order, err = q.CreateOrder(r.Context(), database.CreateOrderParams{
+				Customerid:   userInfo.ID,
+				Restaurantid: req.RestaurantID,
+				Address:      delivery.text,
+				AddressID:    delivery.addressID,
+				DeliveryLat:  sql.NullFloat64{Float64: delivery.point.Lat, Valid: true},
+				DeliveryLng:  sql.NullFloat64{Float64: delivery.point.Lng, Valid: true},
+			})
+			if err != nil {
+				return fmt.Errorf("create order: %w", err)
+			}
+			items = make([]database.Orderitem, 0, len(req.Items))
+			itemOptions = make(map[int32][]ordersStruct.Option)
+			for i, line := range req.Items {
+				added, err := q.AddItem(r.Context(), database.AddItemParams{
+					OrderID:      order.ID,
+					Quanity:      line.Quantity,
+					OptionsPrice: optionsPrice[i],
+					MenuItemID:   line.MenuitemID,
+				})
+				if err != nil {
+					return fmt.Errorf("add item: %w", err)
+				}
+				// deltas were checked against the price when created, a later price cut may still undercut them
+				if added.UnitPrice+added.OptionsPrice < 0 {
+					return fmt.Errorf("item %v: %w", added.MenuItemID, errNegativePrice)
+				}
+				items = append(items, added)
+				if len(line.OptionIDs) == 0 {
+					continue
+				}
+				snapshot, err := q.AddItemOptions(r.Context(), database.AddItemOptionsParams{
+					OrderitemID: added.ID,
+					Currency:    added.Currency,
+					OptionIds:   line.OptionIDs,
+				})
+				if err != nil {
+					return fmt.Errorf("add item options: %w", err)
+				}
+				itemOptions[added.ID] = ordersStruct.OptionsByItem(snapshot)[added.ID]
+			}
+			// prices are copied into orderitem so later menu edits don't change what the customer pays
+			subtotal := money.New(0, items[0].Currency)
+			for _, v := range items {
+				subtotal, err = subtotal.Add(ordersStruct.ItemTotal(v.UnitPrice, v.OptionsPrice, v.Quanity, v.Currency))
+				if err != nil {
+					return fmt.Errorf("sum items: %w", err)
+				}
+			}
+			fee := money.New(0, subtotal.Currency)
+			var zoneID sql.NullInt32
+			if len(zones) > 0 {
+				terms := make([]deliveryZone.Terms, 0, len(zones))
+				for _, z := range zones {
+					terms = append(terms, deliveryZone.Terms{MinOrder: z.MinOrder, DeliveryFee: z.DeliveryFee})
+				}
+				i, ok := deliveryZone.Cheapest(terms, subtotal.Amount)
+				if !ok {
+					return fmt.Errorf("%w: %s", errBelowMinimum, money.New(deliveryZone.LowestMinimum(terms), zones[0].Currency))
+				}
+				fee = money.New(zones[i].DeliveryFee, zones[i].Currency)
+				zoneID = sql.NullInt32{Int32: zones[i].ID, Valid: true}
+			}
+			total, err := subtotal.Add(fee)
+			if err != nil {
+				return fmt.Errorf("add delivery fee: %w", err)
+			}
+			order, err = q.SetOrderTotals(r.Context(), database.SetOrderTotalsParams{
+				ID:             order.ID,
+				Subtotal:       subtotal.Amount,
+				DeliveryFee:    fee.Amount,
+				Total:          total.Amount,
+				Currency:       total.Currency,
+				DeliveryZoneID: zoneID,
+			})
+			if err != nil {
+				return fmt.Errorf("set totals: %w", err)
+			}
+			return nil
 		})
+		if errors.Is(err, errBelowMinimum) {
+			response.Error(log, w, r, err.Error(), sl.Err(err).String(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, errNegativePrice) {
+			response.Error(log, w, r, err.Error(), sl.Err(err).String(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, money.ErrCurrencyMismatch) {
+			response.Error(log, w, r,
+				"the restaurant prices its menu or delivery in different currencies, the order can't be summed",
+				sl.Err(err).String(),
+				http.StatusConflict)
+			return
+		}
 		if err != nil {
-			response.Error(log, w, r, "something went wrong", "failed to add items", http.StatusInternalServerError)
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
 			return
 		}
+		publisher.Publish(r.Context(), order.ID, events.TypePlaced, order.Status)
 		log.Info("successfully added items")
 
+		resp := Response{
+			OrderID:      order.ID,
+			RestaurantID: req.RestaurantID,
+			Status:       order.Status,
+			CreatedAt:    order.CreatedAt.Time.Format(time.RFC3339),
+			Address:      order.Address,
+			AddressID:    order.AddressID.Int32,
+			Location:     &delivery.point,
+			DeliveryFee:  money.New(order.DeliveryFee, order.Currency),
+			Total:        money.New(order.Total, order.Currency),
+			Items:        make([]item, 0, len(items)),
+		}
+		for _, v := range items {
+			resp.Items = append(resp.Items, item{
+				MenuitemID: v.MenuItemID,
+				ItemName:   v.ItemName,
+				ItemPrice:  money.New(v.UnitPrice, v.Currency),
+				Quantity:   v.Quanity,
+				Options:    ordersStruct.ItemOptions(itemOptions, v.ID),
+				Total:      ordersStruct.ItemTotal(v.UnitPrice, v.OptionsPrice, v.Quanity, v.Currency),
+			})
+		}
+
 		render.Status(r, http.StatusCreated)
-		render.JSON(w, r, Response{
-			order.ID,
-			req.RestaurantID,
-			order.Status,
-			order.CreatedAt.Time.Format(time.RFC3339),
-			order.Address,
-			req.Items,
+		render.JSON(w, r, resp)
+	}
+}
+
+func groupsByItem(groups []database.OptionGroup, options []database.Option) map[int32][]menuOptions.Group {
+	byGroup := make(map[int32][]menuOptions.Option, len(groups))
+	for _, o := range options {
+		byGroup[o.GroupID] = append(byGroup[o.GroupID], menuOptions.Option{
+			ID:         o.ID,
+			Name:       o.Name,
+			PriceDelta: o.PriceDelta,
+			Available:  o.Available,
+		})
+	}
+	byItem := make(map[int32][]menuOptions.Group)
+	for _, g := range groups {
+		byItem[g.MenuItemID] = append(byItem[g.MenuItemID], menuOptions.Group{
+			ID:       g.ID,
+			Name:     g.Name,
+			Kind:     g.Kind,
+			Min:      g.MinSelect,
+			Max:      g.MaxSelect,
+			Required: g.Required,
+			Options:  byGroup[g.ID],
+		})
+	}
+	return byItem
+}
+
+// delivery is where the order goes, it is copied onto the order so later address book edits don't move it.
+type delivery struct {
+	text      string
+	addressID sql.NullInt32
+	point     geo.Point
+	// approximate is set when the point was made up by a geocoder that doesn't know real addresses.
+	approximate bool
+}
+
+// deliveryAddress picks the saved address by id, then the free text address, then the default saved
+// address and at last the address given at registration. Free text is geocoded unless its location is pinned.
+func deliveryAddress(
+	ctx context.Context,
+	req Request,
+	user database.User,
+	addresses addressGetter,
+	geocoder geocode.Geocoder,
+) (delivery, error) {
+	text := strings.TrimSpace(req.Address)
+	if req.AddressID != 0 && text != "" {
+		return delivery{}, errAddressConflict
+	}
+	if req.Location != nil && text == "" {
+		return delivery{}, errLocationNoAddress
+	}
+
+	if req.AddressID != 0 {
+		saved, err := addresses.GetAddressByID(ctx, database.GetAddressByIDParams{
+			ID:     req.AddressID,
+			UserID: user.ID,
 		})
+		if err != nil {
+			return delivery{}, err
+		}
+		return savedDelivery(saved), nil
+	}
+
+	if text == "" {
+		saved, err := addresses.GetDefaultAddress(ctx, user.ID)
+		if err == nil {
+			return savedDelivery(saved), nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return delivery{}, err
+		}
+		if !user.Address.Valid || strings.TrimSpace(user.Address.String) == "" {
+			return delivery{}, errNoAddress
+		}
+		text = strings.TrimSpace(user.Address.String)
+	}
+
+	point, err := geocode.Locate(ctx, geocoder, text, req.Location)
+	if err != nil {
+		return delivery{}, err
+	}
+	return delivery{
+		text:        text,
+		point:       point,
+		approximate: req.Location == nil && geocode.IsApproximate(geocoder),
+	}, nil
+}
+
+func savedDelivery(saved database.Address) delivery {
+	return delivery{
+		text:        addressStruct.Parts(saved).String(),
+		addressID:   sql.NullInt32{Int32: saved.ID, Valid: true},
+		point:       geo.Point{Lat: saved.Lat, Lng: saved.Lng},
+		approximate: !saved.LocatedExactly,
+	}
+}
+
+// coveringZones lists the zones of the restaurant that cover the point. The zone is picked once the subtotal
+// is known, so a zone with a lower minimum still takes an order too small for the cheapest one.
+// A restaurant without zones delivers everywhere, so the list is empty and the order has no delivery fee.
+// An approximate point can't be checked against zones at all.
+func coveringZones(ctx context.Context, zones zoneGetter, restaurantID int32, d delivery) ([]database.DeliveryZone, error) {
+	if d.approximate {
+		count, err := zones.CountDeliveryZones(ctx, restaurantID)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, errApproximateLocation
+		}
+		return nil, nil
+	}
+
+	covering, err := zones.GetDeliveryZonesForPoint(ctx, database.GetDeliveryZonesForPointParams{
+		RestaurantID: restaurantID,
+		Lat:          d.point.Lat,
+		Lng:          d.point.Lng,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(covering) > 0 {
+		return covering, nil
+	}
+	count, err := zones.CountDeliveryZones(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errOutsideZones
 	}
+	return nil, nil
 }
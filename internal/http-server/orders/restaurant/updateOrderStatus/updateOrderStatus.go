@@ -0,0 +1,139 @@
+package updateOrderStatus
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/orderStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/events"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type Response struct {
+	OrderID int32  `json:"order_id" example:"12"`
+	Status  string `json:"status" example:"accepted"`
+}
+
+type orderGetter interface {
+	GetOrderByID(ctx context.Context, id int32) (database.Order, error)
+}
+
+type statusSetter interface {
+	SetOrderStatus(ctx context.Context, arg database.SetOrderStatusParams) (database.Order, error)
+}
+
+type dispatcher interface {
+	Dispatch(orderID int32)
+}
+
+type publisher interface {
+	Publish(ctx context.Context, orderID int32, eventType, status string)
+}
+
+// Orders godoc
+// @Summary Изменение статуса заказа рестораном
+// @Description Ресторан принимает (accept), начинает готовить (preparing) или отмечает готовым к выдаче (ready) свой заказ. Готовый заказ без курьера автоматически предлагается курьерам
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path int true "ID Заказа"
+// @Success 200 {object} updateOrderStatus.Response "Статус заказа изменен"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Заказ не найден"
+// @Failure 409 {object} response.Response "Недопустимый переход статуса"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /orders/{id}/accept [patch]
+// @Router /orders/{id}/preparing [patch]
+// @Router /orders/{id}/ready [patch]
+// @Security BearerAuth
+func New(
+	log *slog.Logger,
+	getter orderGetter,
+	setter statusSetter,
+	dispatcher dispatcher,
+	publisher publisher,
+	target orderStatus.Status,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http_server.orders.restaurant.updateOrderStatus.New"
+		log = log.With(slog.String("op", op),
+			slog.String("target_status", target.String()),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		orderID := chi.URLParam(r, "id")
+		if orderID == "" {
+			response.Error(log, w, r, "Missing orderID", "no order id provided", http.StatusBadRequest)
+			return
+		}
+
+		parsedOrderID, err := strconv.ParseInt(orderID, 10, 32)
+		if err != nil || parsedOrderID < 1 {
+			response.Error(log, w, r, "Wrong orderID", "Invalid orderID", http.StatusBadRequest)
+			return
+		}
+
+		order, err := getter.GetOrderByID(r.Context(), int32(parsedOrderID))
+		if err != nil {
+			response.Error(log, w, r, "No order", "cannot get order", http.StatusNotFound)
+			return
+		}
+
+		if order.Restaurantid != user.RestaurantID {
+			response.Error(log, w, r, "Access denied", "order belongs to another restaurant", http.StatusForbidden)
+			return
+		}
+
+		current := orderStatus.Status(order.Status)
+		if !orderStatus.CanTransition(current, target) {
+			response.Error(log, w, r,
+				fmt.Sprintf("cannot change status from %s to %s", current, target),
+				"forbidden transition",
+				http.StatusConflict)
+			return
+		}
+
+		updated, err := setter.SetOrderStatus(r.Context(), database.SetOrderStatusParams{
+			NewStatus: target.String(),
+			ID:        order.ID,
+			OldStatus: current.String(),
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "Order status has changed, try again", "status changed concurrently", http.StatusConflict)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "Can not update order", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		publisher.Publish(r.Context(), updated.ID, events.TypeStatusChanged, updated.Status)
+		if target == orderStatus.ReadyForPickup && !updated.Courierid.Valid {
+			dispatcher.Dispatch(updated.ID)
+		}
+
+		log.Info("order status changed")
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			OrderID: updated.ID,
+			Status:  updated.Status,
+		})
+	}
+}
@@ -2,47 +2,92 @@ package GetRestaurants
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/pagination"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/restaurantStatus"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
+	"time"
 )
 
 type restaurantsGetter interface {
-	GetUsersByRole(ctx context.Context, userRole string) ([]database.User, error)
+	GetRestaurants(ctx context.Context, arg database.GetRestaurantsParams) ([]database.Restaurant, error)
+}
+
+type scheduleGetter interface {
+	GetRestaurantHoursByRestaurantIDs(ctx context.Context, dollar_1 []int32) ([]database.RestaurantHour, error)
+	GetRestaurantHolidaysByRestaurantIDs(ctx context.Context, dollar_1 []int32) ([]database.RestaurantHoliday, error)
 }
 
 type Response struct {
-	Restaurants []Restaurant `json:"restaurants"`
+	Items []Restaurant `json:"items"`
+	pagination.Cursor
 }
 
 type Restaurant struct {
 	Name         string `json:"name" example:"Mac"`
 	Address      string `json:"address" example:"123 street 1"`
 	Phone        string `json:"phone" example:"89055463333"`
+	Description  string `json:"description,omitempty" example:"burgers and fries"`
+	Cuisine      string `json:"cuisine,omitempty" example:"american"`
+	LogoURL      string `json:"logo_url,omitempty" example:"https://example.com/logo.png"`
 	RestaurantID int32  `json:"restaurant_id" example:"1"`
+	restaurantStatus.Status
 }
 
 // Restaurants godoc
 // @Summary Получение всех Ресторанов
-// @Description Возвращает полную информацию по всем ресторанам(Айди, имя, адрес, телефон)
+// @Description Возвращает полную информацию по ресторанам(Айди, имя, адрес, телефон, описание, кухня, логотип) и открыты ли они сейчас. Если переданы lat и lng, остаются только рестораны, в зону доставки которых попадает точка; рестораны без зон доставки доставляют везде. Список отдается страницами: next_cursor передается в cursor для получения следующей страницы
 // @Tags Restaurants
 // @Accept json
 // @Produce json
+// @Param limit query int false "Размер страницы (1-100, по умолчанию 20)"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param name query string false "Поиск по части названия"
+// @Param cuisine query string false "Фильтр по кухне"
+// @Param lat query number false "Широта адреса доставки, передается вместе с lng"
+// @Param lng query number false "Долгота адреса доставки, передается вместе с lat"
 // @Success 200 {object} GetRestaurants.Response "Рестораны успешно получены"
+// @Failure 400 {object} response.Response "Некорректные данные"
 // @Failure 500 {object} response.Response "Ошибка сервера"
 // @Router /restaurants [get]
-func New(log *slog.Logger, getter restaurantsGetter) http.HandlerFunc {
+func New(log *slog.Logger, getter restaurantsGetter, schedules scheduleGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "http-server.restaurants.GetRestaurants"
 		log = log.With(slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())))
 
-		restaurants, err := getter.GetUsersByRole(r.Context(), "restaurant")
+		params, err := pagination.FromRequest(r)
+		if err != nil {
+			response.Error(log, w, r, err.Error(), "invalid pagination", http.StatusBadRequest)
+			return
+		}
+
+		point, err := pagination.PointParam(r)
+		if err != nil {
+			response.Error(log, w, r, err.Error(), "invalid delivery point", http.StatusBadRequest)
+			return
+		}
+		var lat, lng sql.NullFloat64
+		if point != nil {
+			lat = sql.NullFloat64{Float64: point.Lat, Valid: true}
+			lng = sql.NullFloat64{Float64: point.Lng, Valid: true}
+		}
+
+		restaurants, err := getter.GetRestaurants(r.Context(), database.GetRestaurantsParams{
+			Name:      pagination.StringParam(r, "name"),
+			Cuisine:   pagination.StringParam(r, "cuisine"),
+			AfterID:   params.After,
+			Lat:       lat,
+			Lng:       lng,
+			PageLimit: params.QueryLimit(),
+		})
 		if err != nil {
 			response.Error(log, w, r,
 				"can not get restaurants",
@@ -51,17 +96,56 @@ func New(log *slog.Logger, getter restaurantsGetter) http.HandlerFunc {
 			return
 		}
 
+		ids := make([]int32, 0, len(restaurants))
+		for _, i := range restaurants {
+			ids = append(ids, i.ID)
+		}
+		// one extra restaurant was fetched to know whether there is a next page
+		pageRows, nextCursor := pagination.Cut(ids, params.Limit)
+		restaurants = restaurants[:pageRows]
+		ids = ids[:pageRows]
+
+		// schedules are loaded only for the restaurants on the page
+		pageHours, err := schedules.GetRestaurantHoursByRestaurantIDs(r.Context(), ids)
+		if err != nil {
+			response.Error(log, w, r, "can not get restaurants", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		pageHolidays, err := schedules.GetRestaurantHolidaysByRestaurantIDs(r.Context(), ids)
+		if err != nil {
+			response.Error(log, w, r, "can not get restaurants", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		hours := make(map[int32][]database.RestaurantHour)
+		for _, h := range pageHours {
+			hours[h.RestaurantID] = append(hours[h.RestaurantID], h)
+		}
+		holidays := make(map[int32][]database.RestaurantHoliday)
+		for _, h := range pageHolidays {
+			holidays[h.RestaurantID] = append(holidays[h.RestaurantID], h)
+		}
+
+		now := time.Now()
 		restaurantList := make([]Restaurant, 0, len(restaurants))
 		for _, i := range restaurants {
+			status, err := restaurantStatus.Make(i, hours[i.ID], holidays[i.ID], now)
+			if err != nil {
+				log.Error("failed to get restaurant status", slog.Int("restaurant_id", int(i.ID)), sl.Err(err))
+			}
 			restaurantList = append(restaurantList, Restaurant{
-				Name:         i.UserName.String,
-				Address:      i.Address.String,
+				Name:         i.Name,
+				Address:      i.Address,
 				Phone:        i.Phone,
+				Description:  i.Description.String,
+				Cuisine:      i.Cuisine.String,
+				LogoURL:      i.LogoUrl.String,
 				RestaurantID: i.ID,
+				Status:       status,
 			})
 		}
 		render.JSON(w, r, Response{
-			Restaurants: restaurantList,
+			Items:  restaurantList,
+			Cursor: nextCursor,
 		})
 	}
 }
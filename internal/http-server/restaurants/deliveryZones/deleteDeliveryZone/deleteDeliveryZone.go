@@ -0,0 +1,69 @@
+package deleteDeliveryZone
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type zoneDeleter interface {
+	DeleteDeliveryZone(ctx context.Context, arg database.DeleteDeliveryZoneParams) (int64, error)
+}
+
+// Restaurants godoc
+// @Summary Удаление зоны доставки
+// @Description Удаляет зону доставки. Оформленные заказы сохраняют стоимость доставки. Когда удалена последняя зона, ресторан снова доставляет по любому адресу
+// @Tags Restaurants
+// @Produce json
+// @Param id path int true "ID зоны"
+// @Success 204 "Зона удалена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Зона не найдена"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/me/zones/{id} [delete]
+// @Security BearerAuth
+func New(log *slog.Logger, deleter zoneDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.deleteDeliveryZone"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		zoneID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || zoneID < 1 {
+			response.Error(log, w, r, "invalid zone ID", "failed to parse zone ID", http.StatusBadRequest)
+			return
+		}
+
+		deleted, err := deleter.DeleteDeliveryZone(r.Context(), database.DeleteDeliveryZoneParams{
+			ID:           int32(zoneID),
+			RestaurantID: user.RestaurantID,
+		})
+		if err != nil {
+			response.Error(log, w, r, "failed to delete", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		if deleted == 0 {
+			response.Error(log, w, r, "zone not found", "no zone in restaurant", http.StatusNotFound)
+			return
+		}
+
+		log.Info("delivery zone deleted", slog.Int("zone_id", int(zoneID)))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
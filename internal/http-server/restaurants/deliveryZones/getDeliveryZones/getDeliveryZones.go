@@ -0,0 +1,68 @@
+package getDeliveryZones
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/zoneStruct"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type Response struct {
+	Zones []zoneStruct.Zone `json:"zones"`
+}
+
+type zonesGetter interface {
+	GetDeliveryZonesByRestaurantID(ctx context.Context, restaurantID int32) ([]database.DeliveryZone, error)
+}
+
+// Restaurants godoc
+// @Summary Зоны доставки ресторана
+// @Description Возвращает зоны доставки ресторана с минимальной суммой заказа и стоимостью доставки, самые дешевые первыми. Пустой список значит, что ресторан доставляет по любому адресу
+// @Tags Restaurants
+// @Produce json
+// @Param id path int true "ID ресторана"
+// @Success 200 {object} getDeliveryZones.Response "Зоны получены"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/{id}/zones [get]
+func New(log *slog.Logger, getter zonesGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.getDeliveryZones"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		restaurantID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || restaurantID < 1 {
+			response.Error(log, w, r, "invalid restaurant ID", "failed to parse restaurant ID", http.StatusBadRequest)
+			return
+		}
+
+		rows, err := getter.GetDeliveryZonesByRestaurantID(r.Context(), int32(restaurantID))
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		resp := Response{Zones: make([]zoneStruct.Zone, 0, len(rows))}
+		for _, row := range rows {
+			zone, err := zoneStruct.FromRow(row)
+			if err != nil {
+				response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+				return
+			}
+			resp.Zones = append(resp.Zones, zone)
+		}
+
+		log.Info("delivery zones sent", slog.Int("restaurant_id", int(restaurantID)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, resp)
+	}
+}
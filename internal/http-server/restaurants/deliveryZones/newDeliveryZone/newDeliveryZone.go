@@ -0,0 +1,138 @@
+package newDeliveryZone
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/deliveryZone"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/zoneStruct"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	Name        string      `json:"name" validate:"required" example:"Center"`
+	Kind        string      `json:"kind" validate:"required" example:"radius"`
+	Center      *geo.Point  `json:"center,omitempty"`
+	RadiusM     float64     `json:"radius_m,omitempty" example:"3000"`
+	Polygon     []geo.Point `json:"polygon,omitempty"`
+	MinOrder    money.Money `json:"min_order"`
+	DeliveryFee money.Money `json:"delivery_fee"`
+}
+
+type zoneCreater interface {
+	CreateDeliveryZone(ctx context.Context, arg database.CreateDeliveryZoneParams) (database.DeliveryZone, error)
+}
+
+// Restaurants godoc
+// @Summary Добавление зоны доставки
+// @Description Добавляет зону доставки ресторана: круг (kind radius, center и radius_m в метрах) или многоугольник (kind polygon, от 3 до 100 вершин). У зоны своя минимальная сумма заказа и стоимость доставки в одной валюте. Пока у ресторана нет зон, он доставляет по любому адресу
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param request body newDeliveryZone.Request true "Зона доставки"
+// @Success 201 {object} zoneStruct.Zone "Зона добавлена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/me/zones [post]
+// @Security BearerAuth
+func New(log *slog.Logger, creater zoneCreater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.newDeliveryZone"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		area := deliveryZone.Area{
+			Kind:    deliveryZone.Kind(req.Kind),
+			Center:  req.Center,
+			RadiusM: req.RadiusM,
+			Polygon: req.Polygon,
+		}
+		if err := area.Validate(); err != nil {
+			response.Error(log, w, r, err.Error(), "invalid zone area", http.StatusBadRequest)
+			return
+		}
+
+		if req.MinOrder.Amount < 0 || req.DeliveryFee.Amount < 0 {
+			response.Error(log, w, r, "min_order and delivery_fee can not be negative", "negative money", http.StatusBadRequest)
+			return
+		}
+		minOrder := money.New(req.MinOrder.Amount, req.MinOrder.Currency)
+		fee := money.New(req.DeliveryFee.Amount, req.DeliveryFee.Currency)
+		if minOrder.Currency != fee.Currency {
+			response.Error(log, w, r, "min_order and delivery_fee must be in one currency", "currency mismatch", http.StatusBadRequest)
+			return
+		}
+
+		params := database.CreateDeliveryZoneParams{
+			RestaurantID: user.RestaurantID,
+			Name:         req.Name,
+			Kind:         area.Kind.String(),
+			Polygon:      json.RawMessage("[]"),
+			MinOrder:     minOrder.Amount,
+			DeliveryFee:  fee.Amount,
+			Currency:     fee.Currency,
+		}
+		switch area.Kind {
+		case deliveryZone.Radius:
+			params.CenterLat = sql.NullFloat64{Float64: area.Center.Lat, Valid: true}
+			params.CenterLng = sql.NullFloat64{Float64: area.Center.Lng, Valid: true}
+			params.RadiusM = sql.NullFloat64{Float64: area.RadiusM, Valid: true}
+		case deliveryZone.Polygon:
+			polygon, err := json.Marshal(area.Polygon)
+			if err != nil {
+				response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+				return
+			}
+			params.Polygon = polygon
+		}
+
+		saved, err := creater.CreateDeliveryZone(r.Context(), params)
+		if err != nil {
+			response.Error(log, w, r, "failed to create", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		zone, err := zoneStruct.FromRow(saved)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("delivery zone created", slog.Int("zone_id", int(saved.ID)))
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, zone)
+	}
+}
@@ -2,18 +2,33 @@ package getRestaurantByID
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/restaurantStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 type RestaurantGetter interface {
-	GetRestaurantAndMenuByID(ctx context.Context, id int32) ([]database.GetRestaurantAndMenuByIDRow, error)
+	GetRestaurantByID(ctx context.Context, id int32) (database.Restaurant, error)
+}
+
+type ScheduleGetter interface {
+	GetRestaurantHours(ctx context.Context, restaurantID int32) ([]database.RestaurantHour, error)
+	GetRestaurantHolidays(ctx context.Context, restaurantID int32) ([]database.RestaurantHoliday, error)
+}
+
+type MenuGetter interface {
+	GetMenu(ctx context.Context, restaurantID int32) ([]database.Menuitem, error)
 }
 
 type Response struct {
@@ -21,19 +36,23 @@ type Response struct {
 	RestaurantName    string `json:"restaurant_name" example:"mac"`
 	RestaurantAddress string `json:"restaurant_address" example:"112 address"`
 	RestaurantPhone   string `json:"restaurant_phone" example:"89053435656"`
+	Description       string `json:"description,omitempty" example:"burgers and fries"`
+	Cuisine           string `json:"cuisine,omitempty" example:"american"`
+	LogoURL           string `json:"logo_url,omitempty" example:"https://example.com/logo.png"`
 	MenuItems         []item `json:"menu_items"`
+	restaurantStatus.Status
 }
 
 type item struct {
-	ItemID          int32   `json:"item_id" example:"1"`
-	ItemName        string  `json:"item_name" example:"cheeseburger"`
-	ItemPrice       float64 `json:"item_price" example:"122.00"`
-	ItemDescription string  `json:"item_description" example:"burger with cheese"`
+	ItemID          int32       `json:"item_id" example:"1"`
+	ItemName        string      `json:"item_name" example:"cheeseburger"`
+	ItemPrice       money.Money `json:"item_price"`
+	ItemDescription string      `json:"item_description" example:"burger with cheese"`
 }
 
 // Restaurants godoc
 // @Summary Получение Ресторана по айди
-// @Description Возвращает полную информацию по ресторану(Айди, имя, адрес, телефон) и меню по айди
+// @Description Возвращает полную информацию по ресторану(Айди, имя, адрес, телефон, описание, кухня, логотип), открыт ли он сейчас и меню по айди
 // @Tags Restaurants
 // @Accept json
 // @Produce json
@@ -41,8 +60,9 @@ type item struct {
 // @Success 200 {object} getRestaurantByID.Response "Ресторан успешно получен"
 // @Failure 400 {object} response.Response "Некорректные данные"
 // @Failure 404 {object} response.Response "Ресторан не найден"
+// @Failure 500 {object} response.Response "Ошибка сервера"
 // @Router /restaurants/{id} [get]
-func New(log *slog.Logger, getter RestaurantGetter) http.HandlerFunc {
+func New(log *slog.Logger, getter RestaurantGetter, menuGetter MenuGetter, schedules ScheduleGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "http.restaurants.getRestaurantByID"
 		log = log.With(
@@ -66,30 +86,54 @@ func New(log *slog.Logger, getter RestaurantGetter) http.HandlerFunc {
 			return
 		}
 
-		restaurant, err := getter.GetRestaurantAndMenuByID(r.Context(), int32(parsedRestaurantId))
-		if err != nil {
+		restaurant, err := getter.GetRestaurantByID(r.Context(), int32(parsedRestaurantId))
+		if errors.Is(err, sql.ErrNoRows) {
 			response.Error(log, w, r, "Not Found", "no restaurant by folowing ID", http.StatusNotFound)
 			return
 		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		menu, err := menuGetter.GetMenu(r.Context(), restaurant.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
 
-		if len(restaurant) == 0 {
-			response.Error(log, w, r, "Not Found", "empty list", http.StatusNotFound)
+		hours, err := schedules.GetRestaurantHours(r.Context(), restaurant.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
 			return
 		}
+		holidays, err := schedules.GetRestaurantHolidays(r.Context(), restaurant.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		status, err := restaurantStatus.Make(restaurant, hours, holidays, time.Now())
+		if err != nil {
+			log.Error("failed to get restaurant status", sl.Err(err))
+		}
 
 		resp := Response{
-			RestaurantID:      restaurant[0].RestaurantID,
-			RestaurantName:    restaurant[0].RestaurantName.String,
-			RestaurantAddress: restaurant[0].RestaurantAddress.String,
-			RestaurantPhone:   restaurant[0].RestaurantPhone,
-			MenuItems:         make([]item, 0, len(restaurant)),
+			RestaurantID:      restaurant.ID,
+			RestaurantName:    restaurant.Name,
+			RestaurantAddress: restaurant.Address,
+			RestaurantPhone:   restaurant.Phone,
+			Description:       restaurant.Description.String,
+			Cuisine:           restaurant.Cuisine.String,
+			LogoURL:           restaurant.LogoUrl.String,
+			MenuItems:         make([]item, 0, len(menu)),
+			Status:            status,
 		}
-		for _, v := range restaurant {
+		for _, v := range menu {
 			if v.Available.Bool {
 				menuItem := item{
-					ItemID:          v.MenuItemID,
-					ItemName:        v.MenuItemName,
-					ItemPrice:       v.Price,
+					ItemID:          v.ID,
+					ItemName:        v.Name,
+					ItemPrice:       money.New(v.Price, v.Currency),
 					ItemDescription: v.Description.String,
 				}
 				resp.MenuItems = append(resp.MenuItems, menuItem)
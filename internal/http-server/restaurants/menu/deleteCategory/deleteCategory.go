@@ -0,0 +1,69 @@
+package deleteCategory
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type categoryDeleter interface {
+	DeleteMenuCategory(ctx context.Context, arg database.DeleteMenuCategoryParams) (int64, error)
+}
+
+// Restaurants godoc
+// @Summary Удаление категории меню
+// @Description Удаляет категорию меню. Позиции категории остаются в меню без категории
+// @Tags Restaurants
+// @Produce json
+// @Param id path int true "ID категории"
+// @Success 204 "Категория удалена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Категория не найдена"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/categories/{id} [delete]
+// @Security BearerAuth
+func New(log *slog.Logger, deleter categoryDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.deleteCategory"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		categoryID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || categoryID < 1 {
+			response.Error(log, w, r, "invalid category ID", "failed to parse category ID", http.StatusBadRequest)
+			return
+		}
+
+		deleted, err := deleter.DeleteMenuCategory(r.Context(), database.DeleteMenuCategoryParams{
+			ID:           int32(categoryID),
+			RestaurantID: user.RestaurantID,
+		})
+		if err != nil {
+			response.Error(log, w, r, "failed to delete", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		if deleted == 0 {
+			response.Error(log, w, r, "category not found", "no category in restaurant", http.StatusNotFound)
+			return
+		}
+
+		log.Info("menu category deleted", slog.Int("category_id", int(categoryID)))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
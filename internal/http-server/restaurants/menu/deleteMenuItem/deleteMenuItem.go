@@ -0,0 +1,91 @@
+package deleteMenuItem
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type menuItemGetter interface {
+	GetMenuItemByID(ctx context.Context, id int32) (database.Menuitem, error)
+}
+
+// menuItemDeleter only marks the item as deleted: past orders still reference it.
+type menuItemDeleter interface {
+	DeleteMenuItem(ctx context.Context, arg database.DeleteMenuItemParams) (int64, error)
+}
+
+// Restaurants godoc
+// @Summary Удаление позиции меню
+// @Description Скрывает позицию из меню ресторана. Позиция остается в уже оформленных заказах
+// @Tags Restaurants
+// @Produce json
+// @Param id path int true "ID позиции меню"
+// @Success 204 "Позиция удалена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Позиция принадлежит другому ресторану"
+// @Failure 404 {object} response.Response "Позиция не найдена"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/menuItems/{id} [delete]
+// @Security BearerAuth
+func New(log *slog.Logger, getter menuItemGetter, deleter menuItemDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.deleteMenuItem"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		itemID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || itemID < 1 {
+			response.Error(log, w, r, "invalid menu item ID", "failed to parse menu item ID", http.StatusBadRequest)
+			return
+		}
+
+		item, err := getter.GetMenuItemByID(r.Context(), int32(itemID))
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "menu item not found", "no menu item by following id", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		if item.RestaurantID != user.RestaurantID {
+			response.Error(log, w, r, "Access denied", "menu item belongs to another restaurant", http.StatusForbidden)
+			return
+		}
+
+		deleted, err := deleter.DeleteMenuItem(r.Context(), database.DeleteMenuItemParams{
+			ID:           item.ID,
+			RestaurantID: user.RestaurantID,
+		})
+		if err != nil {
+			response.Error(log, w, r, "failed to delete", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		if deleted == 0 {
+			response.Error(log, w, r, "menu item not found", "menu item deleted concurrently", http.StatusNotFound)
+			return
+		}
+
+		log.Info("menu item deleted", slog.Int("menu_item_id", int(item.ID)))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,69 @@
+package deleteOptionGroup
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+type groupDeleter interface {
+	DeleteOptionGroup(ctx context.Context, arg database.DeleteOptionGroupParams) (int64, error)
+}
+
+// Restaurants godoc
+// @Summary Удаление группы опций
+// @Description Удаляет группу опций вместе с ее вариантами. Уже оформленные заказы сохраняют выбранные опции
+// @Tags Restaurants
+// @Produce json
+// @Param id path int true "ID группы опций"
+// @Success 204 "Группа опций удалена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Группа опций не найдена"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/optionGroups/{id} [delete]
+// @Security BearerAuth
+func New(log *slog.Logger, deleter groupDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.deleteOptionGroup"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		groupID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || groupID < 1 {
+			response.Error(log, w, r, "invalid option group ID", "failed to parse option group ID", http.StatusBadRequest)
+			return
+		}
+
+		deleted, err := deleter.DeleteOptionGroup(r.Context(), database.DeleteOptionGroupParams{
+			ID:           int32(groupID),
+			RestaurantID: user.RestaurantID,
+		})
+		if err != nil {
+			response.Error(log, w, r, "failed to delete", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		if deleted == 0 {
+			response.Error(log, w, r, "option group not found", "no option group in restaurant", http.StatusNotFound)
+			return
+		}
+
+		log.Info("option group deleted", slog.Int("group_id", int(groupID)))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
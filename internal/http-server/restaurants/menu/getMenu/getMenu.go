@@ -2,11 +2,15 @@ package getMenu
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -16,21 +20,65 @@ type menuGetter interface {
 	GetMenu(ctx context.Context, restaurantID int32) ([]database.Menuitem, error)
 }
 
+type categoriesGetter interface {
+	GetMenuCategories(ctx context.Context, restaurantID int32) ([]database.MenuCategory, error)
+}
+
+type optionsGetter interface {
+	GetOptionGroupsByRestaurantID(ctx context.Context, restaurantID int32) ([]database.OptionGroup, error)
+	GetOptionsByRestaurantID(ctx context.Context, restaurantID int32) ([]database.Option, error)
+}
+
+type restaurantGetter interface {
+	GetRestaurantByID(ctx context.Context, id int32) (database.Restaurant, error)
+}
+
 type Response struct {
-	RestaurantID int32  `json:"restaurant_id" example:"1"`
-	Menu         []Item `json:"menu"`
+	RestaurantID   int32      `json:"restaurant_id" example:"1"`
+	RestaurantName string     `json:"restaurant_name" example:"Mac"`
+	Cuisine        string     `json:"cuisine,omitempty" example:"american"`
+	LogoURL        string     `json:"logo_url,omitempty" example:"https://example.com/logo.png"`
+	Categories     []Category `json:"categories"`
+	Uncategorized  []Item     `json:"uncategorized"`
+}
+
+type Category struct {
+	ID       int32  `json:"id" example:"1"`
+	Name     string `json:"name" example:"Drinks"`
+	Position int32  `json:"position" example:"3"`
+	Items    []Item `json:"items"`
 }
 
 type Item struct {
-	Name        string  `json:"name" example:"Cheeseburger"`
-	Price       float64 `json:"price" example:"122.00"`
-	Description string  `json:"description" example:"burger with cheese"`
-	Available   bool    `json:"available"`
+	ID           int32         `json:"id" example:"1"`
+	Name         string        `json:"name" example:"Cheeseburger"`
+	Price        money.Money   `json:"price"`
+	Description  string        `json:"description" example:"burger with cheese"`
+	Available    bool          `json:"available"`
+	Position     int32         `json:"position" example:"1"`
+	OptionGroups []OptionGroup `json:"option_groups"`
+}
+
+type OptionGroup struct {
+	ID       int32    `json:"id" example:"1"`
+	Name     string   `json:"name" example:"Size"`
+	Kind     string   `json:"kind" example:"single"`
+	Min      int32    `json:"min" example:"1"`
+	Max      int32    `json:"max" example:"1"`
+	Required bool     `json:"required" example:"true"`
+	Options  []Option `json:"options"`
+}
+
+type Option struct {
+	ID         int32       `json:"id" example:"3"`
+	Name       string      `json:"name" example:"L"`
+	PriceDelta money.Money `json:"price_delta"`
+	Available  bool        `json:"available" example:"true"`
 }
 
 // Restaurants godoc
 // @Summary Получение меню по айди
-// @Description Возвращает меню ресторана по айди
+// @Description Возвращает меню ресторана по айди, сгруппированное по категориям. Категории и позиции внутри них упорядочены по position, позиции без категории выводятся отдельно
 // @Tags Restaurants
 // @Accept json
 // @Produce json
@@ -38,8 +86,15 @@ type Item struct {
 // @Success 200 {object} getMenu.Response "Меню успешно получено"
 // @Failure 400 {object} response.Response "Некорректные данные"
 // @Failure 404 {object} response.Response "Ресторан не найден"
+// @Failure 500 {object} response.Response "Ошибка сервера"
 // @Router /restaurants/{id}/menuItems [get]
-func New(log *slog.Logger, getter menuGetter) http.HandlerFunc {
+func New(
+	log *slog.Logger,
+	getter menuGetter,
+	categoriesGetter categoriesGetter,
+	optionsGetter optionsGetter,
+	restaurantGetter restaurantGetter,
+) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "http.restaurants.getMenu"
 		log = log.With(slog.String("op", op),
@@ -58,26 +113,125 @@ func New(log *slog.Logger, getter menuGetter) http.HandlerFunc {
 		}
 		log.Info("restaurant_id is parsed")
 
-		menu, err := getter.GetMenu(r.Context(), int32(IntRestaurantID))
+		restaurant, err := restaurantGetter.GetRestaurantByID(r.Context(), int32(IntRestaurantID))
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "Not Found", "no restaurant found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		menu, err := getter.GetMenu(r.Context(), restaurant.ID)
 		if err != nil {
 			response.Error(log, w, r, "Not Found", "no menu found", http.StatusNotFound)
 			return
 		}
 
-		resMenu := make([]Item, 0, len(menu))
+		categories, err := categoriesGetter.GetMenuCategories(r.Context(), restaurant.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		groups, err := optionsGetter.GetOptionGroupsByRestaurantID(r.Context(), restaurant.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		options, err := optionsGetter.GetOptionsByRestaurantID(r.Context(), restaurant.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		itemGroups := optionGroupsByItem(groups, options, itemCurrency(menu))
+
+		resCategories := make([]Category, 0, len(categories))
+		categoryIndex := make(map[int32]int, len(categories))
+		for _, c := range categories {
+			categoryIndex[c.ID] = len(resCategories)
+			resCategories = append(resCategories, Category{
+				ID:       c.ID,
+				Name:     c.Name,
+				Position: c.Position,
+				Items:    []Item{},
+			})
+		}
+
+		// menu is already ordered by position, appending keeps that order inside every category
+		uncategorized := []Item{}
 		for _, i := range menu {
-			resMenu = append(resMenu, Item{
+			item := Item{
+				ID:          i.ID,
 				Name:        i.Name,
-				Price:       i.Price,
+				Price:       money.New(i.Price, i.Currency),
 				Description: i.Description.String,
 				Available:   i.Available.Bool,
-			})
+				Position:    i.Position,
+			}
+			item.OptionGroups = itemGroups[i.ID]
+			if item.OptionGroups == nil {
+				item.OptionGroups = []OptionGroup{}
+			}
+			idx, ok := categoryIndex[i.CategoryID.Int32]
+			if !i.CategoryID.Valid || !ok {
+				uncategorized = append(uncategorized, item)
+				continue
+			}
+			resCategories[idx].Items = append(resCategories[idx].Items, item)
 		}
 
 		render.Status(r, http.StatusOK)
 		render.JSON(w, r, Response{
-			RestaurantID: int32(IntRestaurantID),
-			Menu:         resMenu,
+			RestaurantID:   restaurant.ID,
+			RestaurantName: restaurant.Name,
+			Cuisine:        restaurant.Cuisine.String,
+			LogoURL:        restaurant.LogoUrl.String,
+			Categories:     resCategories,
+			Uncategorized:  uncategorized,
 		})
 	}
 }
+
+// optionGroupsByItem nests options into their groups, deltas share the currency of the item.
+func optionGroupsByItem(groups []database.OptionGroup, options []database.Option, currency map[int32]string) map[int32][]OptionGroup {
+	groupItem := make(map[int32]int32, len(groups))
+	for _, g := range groups {
+		groupItem[g.ID] = g.MenuItemID
+	}
+	byGroup := make(map[int32][]Option, len(groups))
+	for _, o := range options {
+		byGroup[o.GroupID] = append(byGroup[o.GroupID], Option{
+			ID:         o.ID,
+			Name:       o.Name,
+			PriceDelta: money.New(o.PriceDelta, currency[groupItem[o.GroupID]]),
+			Available:  o.Available,
+		})
+	}
+	byItem := make(map[int32][]OptionGroup)
+	for _, g := range groups {
+		groupOptions := byGroup[g.ID]
+		if groupOptions == nil {
+			groupOptions = []Option{}
+		}
+		byItem[g.MenuItemID] = append(byItem[g.MenuItemID], OptionGroup{
+			ID:       g.ID,
+			Name:     g.Name,
+			Kind:     g.Kind,
+			Min:      g.MinSelect,
+			Max:      g.MaxSelect,
+			Required: g.Required,
+			Options:  groupOptions,
+		})
+	}
+	return byItem
+}
+
+func itemCurrency(menu []database.Menuitem) map[int32]string {
+	currency := make(map[int32]string, len(menu))
+	for _, i := range menu {
+		currency[i.ID] = i.Currency
+	}
+	return currency
+}
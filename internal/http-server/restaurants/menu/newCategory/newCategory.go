@@ -0,0 +1,93 @@
+package newCategory
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	Name     string `json:"name" validate:"required" example:"Drinks"`
+	Position int32  `json:"position" example:"3"`
+}
+
+type Response struct {
+	ID           int32  `json:"id" example:"1"`
+	RestaurantID int32  `json:"restaurant_id" example:"1"`
+	Name         string `json:"name" example:"Drinks"`
+	Position     int32  `json:"position" example:"3"`
+}
+
+type categoryCreater interface {
+	CreateMenuCategory(ctx context.Context, arg database.CreateMenuCategoryParams) (database.MenuCategory, error)
+}
+
+// Restaurants godoc
+// @Summary Добавление категории меню
+// @Description Создает категорию меню (например, закуски или напитки) ресторана, в котором работает пользователь. Категории выводятся по возрастанию position
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param request body newCategory.Request true "Данные для добавления"
+// @Success 201 {object} newCategory.Response "Категория добавлена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/categories [post]
+// @Security BearerAuth
+func New(log *slog.Logger, creater categoryCreater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.newCategory"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		category, err := creater.CreateMenuCategory(r.Context(), database.CreateMenuCategoryParams{
+			RestaurantID: user.RestaurantID,
+			Name:         req.Name,
+			Position:     req.Position,
+		})
+		if err != nil {
+			response.Error(log, w, r, "failed to create", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("menu category created", slog.Int("category_id", int(category.ID)))
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, Response{
+			ID:           category.ID,
+			RestaurantID: category.RestaurantID,
+			Name:         category.Name,
+			Position:     category.Position,
+		})
+	}
+}
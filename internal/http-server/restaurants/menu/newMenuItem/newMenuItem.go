@@ -3,62 +3,77 @@ package newMenuItem
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
 	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
 	"log/slog"
 	"net/http"
 )
 
 type Request struct {
-	Price       float64 `json:"price" example:"52.2"`
-	Name        string  `json:"name" example:"Burger"`
-	Description string  `json:"description,omitempty" example:"burger with beef"`
-	Available   bool    `json:"available"`
+	Price       money.Money `json:"price"`
+	Name        string      `json:"name" example:"Burger"`
+	Description string      `json:"description,omitempty" example:"burger with beef"`
+	Available   bool        `json:"available"`
+	CategoryID  *int32      `json:"category_id,omitempty" example:"2"`
+	Position    int32       `json:"position" example:"1"`
 }
 
 type Response struct {
-	ID           int32   `json:"id" example:"1"`
-	RestaurantID int32   `json:"restaurant_id" example:"1"`
-	Name         string  `json:"name" example:"Burger"`
-	Price        float64 `json:"price" example:"52.2"`
-	Description  string  `json:"description,omitempty" example:"burger with beef"`
-	Available    bool    `json:"available"`
+	ID           int32       `json:"id" example:"1"`
+	RestaurantID int32       `json:"restaurant_id" example:"1"`
+	Name         string      `json:"name" example:"Burger"`
+	Price        money.Money `json:"price"`
+	Description  string      `json:"description,omitempty" example:"burger with beef"`
+	Available    bool        `json:"available"`
+	CategoryID   *int32      `json:"category_id,omitempty" example:"2"`
+	Position     int32       `json:"position" example:"1"`
 }
 
 type menuItemCreater interface {
 	CreateMenuItem(ctx context.Context, arg database.CreateMenuItemParams) (database.Menuitem, error)
 }
-type userGetter interface {
-	GetUserByID(ctx context.Context, id int32) (database.User, error)
+
+// currencyGetter returns the currency the menu is already priced in, the first item sets it.
+type currencyGetter interface {
+	GetMenuCurrency(ctx context.Context, arg database.GetMenuCurrencyParams) (string, error)
+}
+
+type categoryGetter interface {
+	GetMenuCategoryByID(ctx context.Context, id int32) (database.MenuCategory, error)
 }
 
 // Retaurants godoc
 // @Summary Добавление новой позиции в меню
-// @Description Создает новую позицию в меню рессторана по JWT
+// @Description Создает новую позицию в меню рессторана по JWT. Цена должна быть в валюте остальных позиций меню
 // @Tags Restaurants
 // @Accept json
 // @Produce json
 // @Param request body newMenuItem.Request true "Данные для добавления"
 // @Success 200 {object} newMenuItem.Response "Новая позиция успешно добавлена"
 // @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Категория не найдена"
 // @Failure 500 {object} response.Response "Серверная Ошибка"
 // @Router /restaurants/menuItems [post]
 // @Security BearerAuth
-func New(log *slog.Logger, creater menuItemCreater, userGetter userGetter) http.HandlerFunc {
+func New(log *slog.Logger, creater menuItemCreater, currencies currencyGetter, categories categoryGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "http.restaurants.newMenuItem"
 		log = log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())))
 
-		userID, ok := r.Context().Value("userID").(int32)
+		user, ok := principal.FromContext(r.Context())
 		if !ok {
-			w.WriteHeader(http.StatusUnauthorized)
-			render.JSON(w, r, "Not authorized")
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
 			return
 		}
 
@@ -71,23 +86,48 @@ func New(log *slog.Logger, creater menuItemCreater, userGetter userGetter) http.
 			return
 		}
 
-		userRest, err := userGetter.GetUserByID(r.Context(), userID)
-		if err != nil {
-			response.Error(log, w, r, "Wrong JWT", "No users for following ID", http.StatusUnauthorized)
+		if req.Price.Amount <= 0 {
+			response.Error(log, w, r, "price must be positive", "non positive price", http.StatusBadRequest)
 			return
 		}
+		price := money.New(req.Price.Amount, req.Price.Currency)
 
-		if userRest.UserRole != "restaurant" {
-			response.Error(log, w, r, "access denied", "Wrong role", http.StatusForbidden)
+		// an order is summed in one currency, so the whole menu has to share it
+		currency, err := currencies.GetMenuCurrency(r.Context(), database.GetMenuCurrencyParams{
+			RestaurantID: user.RestaurantID,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		if err == nil && price.Currency != currency {
+			response.Error(log, w, r, fmt.Sprintf("price must be in %s", currency), "currency mismatch", http.StatusBadRequest)
 			return
 		}
 
+		categoryID := sql.NullInt32{}
+		if req.CategoryID != nil {
+			category, err := categories.GetMenuCategoryByID(r.Context(), *req.CategoryID)
+			if errors.Is(err, sql.ErrNoRows) || (err == nil && category.RestaurantID != user.RestaurantID) {
+				response.Error(log, w, r, "category not found", "no category in restaurant", http.StatusNotFound)
+				return
+			}
+			if err != nil {
+				response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+				return
+			}
+			categoryID = sql.NullInt32{Int32: category.ID, Valid: true}
+		}
+
 		newItem, err := creater.CreateMenuItem(r.Context(), database.CreateMenuItemParams{
-			RestaurantID: userID,
+			RestaurantID: user.RestaurantID,
 			Name:         req.Name,
-			Price:        req.Price,
+			Price:        price.Amount,
 			Description:  sql.NullString{String: req.Description, Valid: req.Description != ""},
 			Available:    sql.NullBool{Bool: req.Available, Valid: true},
+			Currency:     price.Currency,
+			CategoryID:   categoryID,
+			Position:     req.Position,
 		})
 
 		if err != nil {
@@ -103,9 +143,18 @@ func New(log *slog.Logger, creater menuItemCreater, userGetter userGetter) http.
 			ID:           newItem.ID,
 			RestaurantID: newItem.RestaurantID,
 			Name:         newItem.Name,
-			Price:        newItem.Price,
+			Price:        money.New(newItem.Price, newItem.Currency),
 			Description:  newItem.Description.String,
 			Available:    newItem.Available.Bool,
+			CategoryID:   nullableID(newItem.CategoryID),
+			Position:     newItem.Position,
 		})
 	}
 }
+
+func nullableID(id sql.NullInt32) *int32 {
+	if !id.Valid {
+		return nil
+	}
+	return &id.Int32
+}
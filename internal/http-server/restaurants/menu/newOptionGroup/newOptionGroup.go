@@ -0,0 +1,266 @@
+package newOptionGroup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/menuOptions"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request describes a group with its options. Max defaults to 1 for single-select and to the number of options for multi-select.
+type Request struct {
+	Name     string          `json:"name" validate:"required" example:"Size"`
+	Kind     string          `json:"kind" validate:"required,oneof=single multi" example:"single"`
+	Min      int32           `json:"min" example:"0"`
+	Max      int32           `json:"max,omitempty" example:"1"`
+	Required bool            `json:"required" example:"true"`
+	Position int32           `json:"position" example:"1"`
+	Options  []OptionRequest `json:"options" validate:"required,min=1,dive"`
+}
+
+type OptionRequest struct {
+	Name       string      `json:"name" validate:"required" example:"L"`
+	PriceDelta money.Money `json:"price_delta"`
+	Available  *bool       `json:"available,omitempty" example:"true"`
+	Position   int32       `json:"position" example:"1"`
+}
+
+type Response struct {
+	ID         int32    `json:"id" example:"1"`
+	MenuItemID int32    `json:"menu_item_id" example:"6"`
+	Name       string   `json:"name" example:"Size"`
+	Kind       string   `json:"kind" example:"single"`
+	Min        int32    `json:"min" example:"1"`
+	Max        int32    `json:"max" example:"1"`
+	Required   bool     `json:"required" example:"true"`
+	Position   int32    `json:"position" example:"1"`
+	Options    []Option `json:"options"`
+}
+
+type Option struct {
+	ID         int32       `json:"id" example:"3"`
+	Name       string      `json:"name" example:"L"`
+	PriceDelta money.Money `json:"price_delta"`
+	Available  bool        `json:"available" example:"true"`
+	Position   int32       `json:"position" example:"1"`
+}
+
+type menuItemGetter interface {
+	GetMenuItemByID(ctx context.Context, id int32) (database.Menuitem, error)
+}
+
+type optionsGetter interface {
+	GetOptionGroupsByMenuItemIDs(ctx context.Context, dollar_1 []int32) ([]database.OptionGroup, error)
+	GetOptionsByMenuItemIDs(ctx context.Context, dollar_1 []int32) ([]database.Option, error)
+}
+
+// groupCreater stores the group and its options in one transaction.
+type groupCreater interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
+}
+
+// Restaurants godoc
+// @Summary Добавление группы опций к позиции меню
+// @Description Создает группу опций (например, размер или добавки) с вариантами и их надбавками к цене. single допускает выбор одной опции, multi — нескольких, min и max ограничивают количество выбранных опций, required требует выбрать хотя бы одну. Отрицательные надбавки всех групп позиции вместе не могут опустить ее цену ниже нуля
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param id path int true "ID позиции меню"
+// @Param request body newOptionGroup.Request true "Группа опций"
+// @Success 201 {object} newOptionGroup.Response "Группа опций добавлена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Позиция не найдена"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/menuItems/{id}/optionGroups [post]
+// @Security BearerAuth
+func New(log *slog.Logger, getter menuItemGetter, optionsGetter optionsGetter, creater groupCreater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.newOptionGroup"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		itemID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || itemID < 1 {
+			response.Error(log, w, r, "invalid menu item ID", "failed to parse menu item ID", http.StatusBadRequest)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		item, err := getter.GetMenuItemByID(r.Context(), int32(itemID))
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && (item.RestaurantID != user.RestaurantID || item.DeletedAt.Valid)) {
+			response.Error(log, w, r, "menu item not found", "no menu item in restaurant", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		group := menuOptions.Group{
+			Name:     req.Name,
+			Kind:     req.Kind,
+			Min:      req.Min,
+			Max:      req.Max,
+			Required: req.Required,
+		}
+		if group.Max == 0 {
+			group.Max = 1
+			if group.Kind == menuOptions.KindMulti {
+				group.Max = int32(len(req.Options))
+			}
+		}
+		for _, o := range req.Options {
+			delta := money.New(o.PriceDelta.Amount, o.PriceDelta.Currency)
+			// deltas are added to the item price, so they have to be in its currency
+			if delta.Currency != item.Currency {
+				response.Error(log, w, r,
+					fmt.Sprintf("option %q: price delta must be in %s", o.Name, item.Currency),
+					"currency mismatch",
+					http.StatusBadRequest)
+				return
+			}
+			group.Options = append(group.Options, menuOptions.Option{Name: o.Name, PriceDelta: delta.Amount})
+		}
+		if err := group.Validate(); err != nil {
+			response.Error(log, w, r, err.Error(), "invalid option group", http.StatusBadRequest)
+			return
+		}
+
+		existing, err := itemGroups(r.Context(), optionsGetter, item.ID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		if cheapest := item.Price + menuOptions.CheapestDelta(append(existing, group)); cheapest < 0 {
+			response.Error(log, w, r,
+				fmt.Sprintf("price deltas can bring the item price down to %s", money.New(cheapest, item.Currency)),
+				"negative item price",
+				http.StatusBadRequest)
+			return
+		}
+
+		var resp Response
+		err = creater.InTx(r.Context(), func(q *database.Queries) error {
+			created, err := q.CreateOptionGroup(r.Context(), database.CreateOptionGroupParams{
+				MenuItemID: item.ID,
+				Name:       group.Name,
+				Kind:       group.Kind,
+				MinSelect:  group.Min,
+				MaxSelect:  group.Max,
+				Required:   group.Required,
+				Position:   req.Position,
+			})
+			if err != nil {
+				return fmt.Errorf("create group: %w", err)
+			}
+			resp = Response{
+				ID:         created.ID,
+				MenuItemID: created.MenuItemID,
+				Name:       created.Name,
+				Kind:       created.Kind,
+				Min:        created.MinSelect,
+				Max:        created.MaxSelect,
+				Required:   created.Required,
+				Position:   created.Position,
+				Options:    make([]Option, 0, len(req.Options)),
+			}
+			for i, o := range req.Options {
+				available := true
+				if o.Available != nil {
+					available = *o.Available
+				}
+				option, err := q.CreateOption(r.Context(), database.CreateOptionParams{
+					GroupID:    created.ID,
+					Name:       o.Name,
+					PriceDelta: group.Options[i].PriceDelta,
+					Available:  available,
+					Position:   o.Position,
+				})
+				if err != nil {
+					return fmt.Errorf("create option: %w", err)
+				}
+				resp.Options = append(resp.Options, Option{
+					ID:         option.ID,
+					Name:       option.Name,
+					PriceDelta: money.New(option.PriceDelta, item.Currency),
+					Available:  option.Available,
+					Position:   option.Position,
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			response.Error(log, w, r, "failed to create", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("option group created", slog.Int("group_id", int(resp.ID)))
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, resp)
+	}
+}
+
+// itemGroups loads the option groups the item already has, only the limits and deltas matter here.
+func itemGroups(ctx context.Context, getter optionsGetter, itemID int32) ([]menuOptions.Group, error) {
+	groups, err := getter.GetOptionGroupsByMenuItemIDs(ctx, []int32{itemID})
+	if err != nil {
+		return nil, err
+	}
+	options, err := getter.GetOptionsByMenuItemIDs(ctx, []int32{itemID})
+	if err != nil {
+		return nil, err
+	}
+	byGroup := make(map[int32][]menuOptions.Option, len(groups))
+	for _, o := range options {
+		byGroup[o.GroupID] = append(byGroup[o.GroupID], menuOptions.Option{ID: o.ID, Name: o.Name, PriceDelta: o.PriceDelta})
+	}
+	result := make([]menuOptions.Group, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, menuOptions.Group{
+			ID:       g.ID,
+			Name:     g.Name,
+			Kind:     g.Kind,
+			Min:      g.MinSelect,
+			Max:      g.MaxSelect,
+			Required: g.Required,
+			Options:  byGroup[g.ID],
+		})
+	}
+	return result, nil
+}
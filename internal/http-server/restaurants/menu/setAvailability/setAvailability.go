@@ -0,0 +1,116 @@
+package setAvailability
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"slices"
+)
+
+var errNotOwned = errors.New("menu items not found or owned by another restaurant")
+
+type Request struct {
+	ItemIDs   []int32 `json:"item_ids" validate:"required,min=1,dive,gt=0" example:"1,2,3"`
+	Available *bool   `json:"available" validate:"required" example:"false"`
+}
+
+type Response struct {
+	ItemIDs   []int32 `json:"item_ids" example:"1,2,3"`
+	Available bool    `json:"available" example:"false"`
+}
+
+// availabilitySetter updates all items in one transaction so a foreign id leaves the menu untouched.
+type availabilitySetter interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
+}
+
+// Restaurants godoc
+// @Summary Массовое изменение доступности позиций меню
+// @Description Включает или выключает доступность сразу нескольких позиций меню ресторана по JWT
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param request body setAvailability.Request true "Позиции и доступность"
+// @Success 200 {object} setAvailability.Response "Доступность изменена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Позиции не найдены или принадлежат другому ресторану"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/menuItems/availability [patch]
+// @Security BearerAuth
+func New(log *slog.Logger, setter availabilitySetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.setAvailability"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		ids := slices.Clone(req.ItemIDs)
+		slices.Sort(ids)
+		ids = slices.Compact(ids)
+
+		var updated []int32
+		err := setter.InTx(r.Context(), func(q *database.Queries) error {
+			var err error
+			updated, err = q.SetMenuItemsAvailability(r.Context(), database.SetMenuItemsAvailabilityParams{
+				Available:    sql.NullBool{Bool: *req.Available, Valid: true},
+				Ids:          ids,
+				RestaurantID: user.RestaurantID,
+			})
+			if err != nil {
+				return fmt.Errorf("set availability: %w", err)
+			}
+			if len(updated) != len(ids) {
+				return errNotOwned
+			}
+			return nil
+		})
+		if errors.Is(err, errNotOwned) {
+			response.Error(log, w, r, "Access denied", errNotOwned.Error(), http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		slices.Sort(updated)
+		log.Info("menu items availability changed", slog.Int("count", len(updated)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			ItemIDs:   updated,
+			Available: *req.Available,
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package updateCategory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request holds only the fields to change, omitted fields keep their current value.
+type Request struct {
+	Name     *string `json:"name,omitempty" example:"Drinks"`
+	Position *int32  `json:"position,omitempty" example:"3"`
+}
+
+type Response struct {
+	ID           int32  `json:"id" example:"1"`
+	RestaurantID int32  `json:"restaurant_id" example:"1"`
+	Name         string `json:"name" example:"Drinks"`
+	Position     int32  `json:"position" example:"3"`
+}
+
+type categoryUpdater interface {
+	UpdateMenuCategory(ctx context.Context, arg database.UpdateMenuCategoryParams) (database.MenuCategory, error)
+}
+
+// Restaurants godoc
+// @Summary Изменение категории меню
+// @Description Переименовывает категорию меню или меняет ее порядок. Не переданные поля не меняются
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param id path int true "ID категории"
+// @Param request body updateCategory.Request true "Поля для изменения"
+// @Success 200 {object} updateCategory.Response "Категория изменена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Категория не найдена"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/categories/{id} [patch]
+// @Security BearerAuth
+func New(log *slog.Logger, updater categoryUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.updateCategory"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		categoryID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || categoryID < 1 {
+			response.Error(log, w, r, "invalid category ID", "failed to parse category ID", http.StatusBadRequest)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if req.Name != nil && *req.Name == "" {
+			response.Error(log, w, r, "name can't be empty", "empty name", http.StatusBadRequest)
+			return
+		}
+
+		params := database.UpdateMenuCategoryParams{
+			ID:           int32(categoryID),
+			RestaurantID: user.RestaurantID,
+		}
+		if req.Name != nil {
+			params.Name = sql.NullString{String: *req.Name, Valid: true}
+		}
+		if req.Position != nil {
+			params.Position = sql.NullInt32{Int32: *req.Position, Valid: true}
+		}
+
+		// categories of other restaurants are reported as missing
+		category, err := updater.UpdateMenuCategory(r.Context(), params)
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "category not found", "no category in restaurant", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "failed to update", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("menu category updated", slog.Int("category_id", int(category.ID)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			ID:           category.ID,
+			RestaurantID: category.RestaurantID,
+			Name:         category.Name,
+			Position:     category.Position,
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package updateMenuItem
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request holds only the fields to change, omitted fields keep their current value.
+type Request struct {
+	Name        *string      `json:"name,omitempty" example:"Burger"`
+	Price       *money.Money `json:"price,omitempty"`
+	Description *string      `json:"description,omitempty" example:"burger with beef"`
+	Available   *bool        `json:"available,omitempty"`
+	CategoryID  *int32       `json:"category_id,omitempty" example:"2"`
+	Position    *int32       `json:"position,omitempty" example:"1"`
+}
+
+type Response struct {
+	ID           int32       `json:"id" example:"1"`
+	RestaurantID int32       `json:"restaurant_id" example:"1"`
+	Name         string      `json:"name" example:"Burger"`
+	Price        money.Money `json:"price"`
+	Description  string      `json:"description,omitempty" example:"burger with beef"`
+	Available    bool        `json:"available"`
+	CategoryID   *int32      `json:"category_id,omitempty" example:"2"`
+	Position     int32       `json:"position" example:"1"`
+}
+
+type menuItemGetter interface {
+	GetMenuItemByID(ctx context.Context, id int32) (database.Menuitem, error)
+}
+
+type menuItemUpdater interface {
+	UpdateMenuItem(ctx context.Context, arg database.UpdateMenuItemParams) (database.Menuitem, error)
+}
+
+// currencyGetter returns the currency of the other items of the menu.
+type currencyGetter interface {
+	GetMenuCurrency(ctx context.Context, arg database.GetMenuCurrencyParams) (string, error)
+}
+
+type categoryGetter interface {
+	GetMenuCategoryByID(ctx context.Context, id int32) (database.MenuCategory, error)
+}
+
+// Restaurants godoc
+// @Summary Изменение позиции меню
+// @Description Изменяет название, цену, описание, доступность, категорию или порядок позиции меню. Не переданные поля не меняются. Новая цена должна быть в валюте остальных позиций меню
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param id path int true "ID позиции меню"
+// @Param request body updateMenuItem.Request true "Поля для изменения"
+// @Success 200 {object} updateMenuItem.Response "Позиция изменена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Позиция принадлежит другому ресторану"
+// @Failure 404 {object} response.Response "Позиция или категория не найдена"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/menuItems/{id} [patch]
+// @Security BearerAuth
+func New(
+	log *slog.Logger,
+	getter menuItemGetter,
+	updater menuItemUpdater,
+	currencies currencyGetter,
+	categories categoryGetter,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.updateMenuItem"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		itemID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || itemID < 1 {
+			response.Error(log, w, r, "invalid menu item ID", "failed to parse menu item ID", http.StatusBadRequest)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if req.Name != nil && *req.Name == "" {
+			response.Error(log, w, r, "name can't be empty", "empty name", http.StatusBadRequest)
+			return
+		}
+		if req.Price != nil && req.Price.Amount <= 0 {
+			response.Error(log, w, r, "price must be positive", "non positive price", http.StatusBadRequest)
+			return
+		}
+
+		item, err := getter.GetMenuItemByID(r.Context(), int32(itemID))
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "menu item not found", "no menu item by following id", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		if item.RestaurantID != user.RestaurantID {
+			response.Error(log, w, r, "Access denied", "menu item belongs to another restaurant", http.StatusForbidden)
+			return
+		}
+
+		params := database.UpdateMenuItemParams{
+			ID:           item.ID,
+			RestaurantID: user.RestaurantID,
+		}
+		if req.Name != nil {
+			params.Name = sql.NullString{String: *req.Name, Valid: true}
+		}
+		if req.Price != nil {
+			price := money.New(req.Price.Amount, req.Price.Currency)
+			currency, err := currencies.GetMenuCurrency(r.Context(), database.GetMenuCurrencyParams{
+				RestaurantID: user.RestaurantID,
+				ExceptID:     item.ID,
+			})
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+				return
+			}
+			if err == nil && price.Currency != currency {
+				response.Error(log, w, r, fmt.Sprintf("price must be in %s", currency), "currency mismatch", http.StatusBadRequest)
+				return
+			}
+			params.Price = sql.NullInt64{Int64: price.Amount, Valid: true}
+			params.Currency = sql.NullString{String: price.Currency, Valid: true}
+		}
+		if req.Description != nil {
+			params.Description = sql.NullString{String: *req.Description, Valid: true}
+		}
+		if req.Available != nil {
+			params.Available = sql.NullBool{Bool: *req.Available, Valid: true}
+		}
+		if req.CategoryID != nil {
+			category, err := categories.GetMenuCategoryByID(r.Context(), *req.CategoryID)
+			if errors.Is(err, sql.ErrNoRows) || (err == nil && category.RestaurantID != user.RestaurantID) {
+				response.Error(log, w, r, "category not found", "no category in restaurant", http.StatusNotFound)
+				return
+			}
+			if err != nil {
+				response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+				return
+			}
+			params.CategoryID = sql.NullInt32{Int32: category.ID, Valid: true}
+		}
+		if req.Position != nil {
+			params.Position = sql.NullInt32{Int32: *req.Position, Valid: true}
+		}
+
+		updated, err := updater.UpdateMenuItem(r.Context(), params)
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "menu item not found", "menu item deleted concurrently", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "failed to update", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("menu item updated", slog.Int("menu_item_id", int(updated.ID)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			ID:           updated.ID,
+			RestaurantID: updated.RestaurantID,
+			Name:         updated.Name,
+			Price:        money.New(updated.Price, updated.Currency),
+			Description:  updated.Description.String,
+			Available:    updated.Available.Bool,
+			CategoryID:   nullableID(updated.CategoryID),
+			Position:     updated.Position,
+		})
+	}
+}
+
+func nullableID(id sql.NullInt32) *int32 {
+	if !id.Valid {
+		return nil
+	}
+	return &id.Int32
+}
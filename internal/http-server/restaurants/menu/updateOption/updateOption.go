@@ -0,0 +1,115 @@
+package updateOption
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Request holds only the fields to change, omitted fields keep their current value.
+type Request struct {
+	Name      *string `json:"name,omitempty" example:"XL"`
+	Available *bool   `json:"available,omitempty" example:"false"`
+}
+
+type Response struct {
+	ID        int32  `json:"id" example:"3"`
+	GroupID   int32  `json:"group_id" example:"1"`
+	Name      string `json:"name" example:"XL"`
+	Available bool   `json:"available" example:"false"`
+	Position  int32  `json:"position" example:"1"`
+}
+
+type optionUpdater interface {
+	UpdateOption(ctx context.Context, arg database.UpdateOptionParams) (database.Option, error)
+}
+
+// Restaurants godoc
+// @Summary Изменение опции
+// @Description Переименовывает опцию или делает ее недоступной для заказа. Не переданные поля не меняются
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param id path int true "ID опции"
+// @Param request body updateOption.Request true "Поля для изменения"
+// @Success 200 {object} updateOption.Response "Опция изменена"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Опция не найдена"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/options/{id} [patch]
+// @Security BearerAuth
+func New(log *slog.Logger, updater optionUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.updateOption"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		optionID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || optionID < 1 {
+			response.Error(log, w, r, "invalid option ID", "failed to parse option ID", http.StatusBadRequest)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if req.Name != nil && *req.Name == "" {
+			response.Error(log, w, r, "name can't be empty", "empty name", http.StatusBadRequest)
+			return
+		}
+
+		params := database.UpdateOptionParams{
+			ID:           int32(optionID),
+			RestaurantID: user.RestaurantID,
+		}
+		if req.Name != nil {
+			params.Name = sql.NullString{String: *req.Name, Valid: true}
+		}
+		if req.Available != nil {
+			params.Available = sql.NullBool{Bool: *req.Available, Valid: true}
+		}
+
+		// options of other restaurants are reported as missing
+		option, err := updater.UpdateOption(r.Context(), params)
+		if errors.Is(err, sql.ErrNoRows) {
+			response.Error(log, w, r, "option not found", "no option in restaurant", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "failed to update", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("option updated", slog.Int("option_id", int(option.ID)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			ID:        option.ID,
+			GroupID:   option.GroupID,
+			Name:      option.Name,
+			Available: option.Available,
+			Position:  option.Position,
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package restaurantEvents
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/events"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/sse"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+type eventsGetter interface {
+	GetRestaurantEventsAfter(ctx context.Context, arg database.GetRestaurantEventsAfterParams) ([]database.OrderEvent, error)
+}
+
+type subscriber interface {
+	Subscribe(filter events.Filter) *events.Subscription
+	Unsubscribe(sub *events.Subscription)
+}
+
+// Restaurants godoc
+// @Summary Поток событий заказов ресторана (SSE)
+// @Description Server-Sent Events о новых заказах и изменениях заказов ресторана, в котором работает пользователь. Без Last-Event-ID поток начинается с текущего момента, с ним пропущенные события досылаются из журнала. Раз в 15 секунд приходит комментарий-heartbeat
+// @Tags Restaurants
+// @Produce text/event-stream
+// @Param Last-Event-ID header int false "ID последнего полученного события"
+// @Success 200 {object} events.Event "Поток событий, в data каждого события JSON"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 500 {object} response.Response "Ошибка сервера"
+// @Router /restaurants/me/events [get]
+// @Security BearerAuth
+func New(log *slog.Logger, eventsGetter eventsGetter, bus subscriber) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.restaurantEvents"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		afterID, resumed, err := sse.LastEventID(r)
+		if err != nil {
+			response.Error(log, w, r, err.Error(), "invalid Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+
+		sub := bus.Subscribe(events.Filter{RestaurantID: user.RestaurantID})
+		defer bus.Unsubscribe(sub)
+
+		var replay sse.Replayer
+		if resumed {
+			replay = func(ctx context.Context, afterID int64, limit int32) ([]events.Event, error) {
+				rows, err := eventsGetter.GetRestaurantEventsAfter(ctx, database.GetRestaurantEventsAfterParams{
+					RestaurantID: user.RestaurantID,
+					AfterID:      afterID,
+					PageLimit:    limit,
+				})
+				if err != nil {
+					return nil, err
+				}
+				res := make([]events.Event, 0, len(rows))
+				for _, row := range rows {
+					res = append(res, events.FromRow(row))
+				}
+				return res, nil
+			}
+		}
+
+		log.Info("restaurant event stream opened", slog.Int("restaurant_id", int(user.RestaurantID)))
+		if err := sse.Serve(w, r, log, sub, afterID, replay); err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		log.Info("restaurant event stream closed")
+	}
+}
@@ -0,0 +1,201 @@
+package setOpeningHours
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/restaurantStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	clockLayout = "15:04"
+	dateLayout  = "2006-01-02"
+)
+
+type Request struct {
+	Weekly   []WeeklyHours `json:"weekly" validate:"dive"`
+	Holidays []Holiday     `json:"holidays" validate:"dive"`
+}
+
+// WeeklyHours is one opening window, weekday 0 is Sunday. Closes before Opens means closing after midnight.
+type WeeklyHours struct {
+	Weekday int32  `json:"weekday" validate:"min=0,max=6" example:"1"`
+	Opens   string `json:"opens" validate:"required" example:"09:00"`
+	Closes  string `json:"closes" validate:"required" example:"23:00"`
+}
+
+// Holiday overrides the weekly hours on a date. Without Opens and Closes the restaurant is closed all day.
+type Holiday struct {
+	Date   string `json:"date" validate:"required" example:"2025-12-31"`
+	Opens  string `json:"opens,omitempty" validate:"required_with=Closes" example:"10:00"`
+	Closes string `json:"closes,omitempty" validate:"required_with=Opens" example:"18:00"`
+}
+
+type Response struct {
+	Weekly   []WeeklyHours `json:"weekly"`
+	Holidays []Holiday     `json:"holidays"`
+	restaurantStatus.Status
+}
+
+// hoursSetter replaces the whole schedule in one transaction.
+type hoursSetter interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
+}
+
+// Restaurants godoc
+// @Summary Установка часов работы ресторана
+// @Description Заменяет недельное расписание и праздничные дни ресторана, в котором работает пользователь. Каждая дата праздника указывается один раз. Время указывается в часовом поясе ресторана
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param request body setOpeningHours.Request true "Расписание"
+// @Success 200 {object} setOpeningHours.Response "Расписание сохранено"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/me/hours [put]
+// @Security BearerAuth
+func New(log *slog.Logger, setter hoursSetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.setOpeningHours"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		weekly := make([]database.CreateRestaurantHoursParams, 0, len(req.Weekly))
+		for _, h := range req.Weekly {
+			opens, closes, err := parseWindow(h.Opens, h.Closes)
+			if err != nil {
+				response.Error(log, w, r, err.Error(), "invalid weekly hours", http.StatusBadRequest)
+				return
+			}
+			weekly = append(weekly, database.CreateRestaurantHoursParams{
+				RestaurantID: user.RestaurantID,
+				Weekday:      h.Weekday,
+				OpensAt:      opens,
+				ClosesAt:     closes,
+			})
+		}
+
+		holidays := make([]database.CreateRestaurantHolidayParams, 0, len(req.Holidays))
+		seen := make(map[time.Time]bool, len(req.Holidays))
+		for _, h := range req.Holidays {
+			day, err := time.Parse(dateLayout, h.Date)
+			if err != nil {
+				response.Error(log, w, r, fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", h.Date), "invalid date", http.StatusBadRequest)
+				return
+			}
+			// a date has at most one override, the table is keyed by it
+			if seen[day] {
+				response.Error(log, w, r, fmt.Sprintf("date %s is listed twice", h.Date), "duplicate holiday", http.StatusBadRequest)
+				return
+			}
+			seen[day] = true
+			params := database.CreateRestaurantHolidayParams{
+				RestaurantID: user.RestaurantID,
+				Day:          day,
+			}
+			if h.Opens != "" {
+				opens, closes, err := parseWindow(h.Opens, h.Closes)
+				if err != nil {
+					response.Error(log, w, r, err.Error(), "invalid holiday hours", http.StatusBadRequest)
+					return
+				}
+				params.OpensAt = sql.NullTime{Time: opens, Valid: true}
+				params.ClosesAt = sql.NullTime{Time: closes, Valid: true}
+			}
+			holidays = append(holidays, params)
+		}
+
+		var status restaurantStatus.Status
+		err := setter.InTx(r.Context(), func(q *database.Queries) error {
+			if err := q.DeleteRestaurantHours(r.Context(), user.RestaurantID); err != nil {
+				return fmt.Errorf("delete hours: %w", err)
+			}
+			for _, h := range weekly {
+				if err := q.CreateRestaurantHours(r.Context(), h); err != nil {
+					return fmt.Errorf("create hours: %w", err)
+				}
+			}
+			if err := q.DeleteRestaurantHolidays(r.Context(), user.RestaurantID); err != nil {
+				return fmt.Errorf("delete holidays: %w", err)
+			}
+			for _, h := range holidays {
+				if err := q.CreateRestaurantHoliday(r.Context(), h); err != nil {
+					return fmt.Errorf("create holiday: %w", err)
+				}
+			}
+
+			restaurant, err := q.GetRestaurantByID(r.Context(), user.RestaurantID)
+			if err != nil {
+				return fmt.Errorf("get restaurant: %w", err)
+			}
+			savedHours, err := q.GetRestaurantHours(r.Context(), user.RestaurantID)
+			if err != nil {
+				return fmt.Errorf("get hours: %w", err)
+			}
+			savedHolidays, err := q.GetRestaurantHolidays(r.Context(), user.RestaurantID)
+			if err != nil {
+				return fmt.Errorf("get holidays: %w", err)
+			}
+			status, err = restaurantStatus.Make(restaurant, savedHours, savedHolidays, time.Now())
+			return err
+		})
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("opening hours updated", slog.Int("restaurant_id", int(user.RestaurantID)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			Weekly:   req.Weekly,
+			Holidays: req.Holidays,
+			Status:   status,
+		})
+	}
+}
+
+func parseWindow(opens, closes string) (time.Time, time.Time, error) {
+	opensAt, err := time.Parse(clockLayout, opens)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time %q, expected HH:MM", opens)
+	}
+	closesAt, err := time.Parse(clockLayout, closes)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid time %q, expected HH:MM", closes)
+	}
+	return opensAt, closesAt, nil
+}
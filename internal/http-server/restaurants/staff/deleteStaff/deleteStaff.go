@@ -0,0 +1,83 @@
+package deleteStaff
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+var errNotStaff = errors.New("user is not a staff member of the restaurant")
+
+// staffDeleter detaches the login and ends its sessions in one transaction.
+type staffDeleter interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
+}
+
+// Restaurants godoc
+// @Summary Удаление сотрудника ресторана
+// @Description Владелец открепляет сотрудника от ресторана и завершает все его сессии. Владельца открепить нельзя
+// @Tags Restaurants
+// @Produce json
+// @Param id path int true "ID пользователя сотрудника"
+// @Success 204 "Сотрудник откреплен"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 404 {object} response.Response "Сотрудник не найден"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/me/staff/{id} [delete]
+// @Security BearerAuth
+func New(log *slog.Logger, deleter staffDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.deleteStaff"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		staffID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+		if err != nil || staffID < 1 {
+			response.Error(log, w, r, "invalid user ID", "failed to parse user ID", http.StatusBadRequest)
+			return
+		}
+
+		err = deleter.InTx(r.Context(), func(q *database.Queries) error {
+			deleted, err := q.DeleteRestaurantStaff(r.Context(), database.DeleteRestaurantStaffParams{
+				RestaurantID: user.RestaurantID,
+				UserID:       int32(staffID),
+			})
+			if err != nil {
+				return err
+			}
+			if deleted == 0 {
+				return errNotStaff
+			}
+			_, err = q.RevokeTokensByUser(r.Context(), int32(staffID))
+			return err
+		})
+		if errors.Is(err, errNotStaff) {
+			response.Error(log, w, r, "staff member not found", "no staff member in restaurant", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "failed to delete", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("staff member removed", slog.Int("user_id", int(staffID)))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
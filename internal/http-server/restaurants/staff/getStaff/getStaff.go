@@ -0,0 +1,62 @@
+package getStaff
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/staffStruct"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+)
+
+type Response struct {
+	Staff []staffStruct.Member `json:"staff"`
+}
+
+type staffGetter interface {
+	GetRestaurantStaff(ctx context.Context, restaurantID int32) ([]database.GetRestaurantStaffRow, error)
+}
+
+// Restaurants godoc
+// @Summary Сотрудники ресторана
+// @Description Возвращает владельца и сотрудников ресторана в порядке добавления
+// @Tags Restaurants
+// @Produce json
+// @Success 200 {object} getStaff.Response "Сотрудники получены"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/me/staff [get]
+// @Security BearerAuth
+func New(log *slog.Logger, getter staffGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.getStaff"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		rows, err := getter.GetRestaurantStaff(r.Context(), user.RestaurantID)
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		resp := Response{Staff: make([]staffStruct.Member, 0, len(rows))}
+		for _, row := range rows {
+			resp.Staff = append(resp.Staff, staffStruct.FromRow(row))
+		}
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, resp)
+	}
+}
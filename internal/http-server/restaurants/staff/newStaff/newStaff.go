@@ -0,0 +1,139 @@
+package newStaff
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/staffStruct"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/hashPassword"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/validation/phoneValidation"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+var errEmailTaken = errors.New("email is already registered")
+
+type Request struct {
+	Email    string `json:"email" validate:"required,email" example:"cook@example.com"`
+	Password string `json:"password" validate:"required" example:"password123"`
+	Phone    string `json:"phone" example:"89035433434"`
+	Name     string `json:"name" example:"Bill"`
+}
+
+// staffSaver creates the login and attaches it to the restaurant in one transaction.
+type staffSaver interface {
+	InTx(ctx context.Context, fn func(q *database.Queries) error) error
+}
+
+// Restaurants godoc
+// @Summary Добавление сотрудника ресторана
+// @Description Владелец ресторана создает логин сотрудника. Сотрудник входит через /login и работает с меню и заказами ресторана, но не может управлять сотрудниками
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param request body newStaff.Request true "Данные сотрудника"
+// @Success 201 {object} staffStruct.Member "Сотрудник добавлен"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 409 {object} response.Response "Email уже зарегистрирован"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/me/staff [post]
+// @Security BearerAuth
+func New(log *slog.Logger, saver staffSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.newStaff"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		if !phoneValidation.IsValidRuPhoneNumber(req.Phone) {
+			response.Error(log, w, r, "failed to validate phone", "invalid phone", http.StatusBadRequest)
+			return
+		}
+
+		hashedPassword, err := hashPassword.HashPassword(req.Password)
+		if err != nil {
+			response.Error(log, w, r, "failed to set password", "failed to hash password", http.StatusInternalServerError)
+			return
+		}
+
+		var savedUser database.User
+		err = saver.InTx(r.Context(), func(q *database.Queries) error {
+			_, err := q.GetUserByEmail(r.Context(), req.Email)
+			if err == nil {
+				return errEmailTaken
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("get user: %w", err)
+			}
+			savedUser, err = q.CreateUser(r.Context(), database.CreateUserParams{
+				Email:        req.Email,
+				HashPassword: hashedPassword,
+				UserRole:     principal.RoleRestaurant,
+				Phone:        req.Phone,
+				UserName:     sql.NullString{String: req.Name, Valid: req.Name != ""},
+			})
+			if err != nil {
+				return fmt.Errorf("create user: %w", err)
+			}
+			if err := q.AddRestaurantStaff(r.Context(), database.AddRestaurantStaffParams{
+				RestaurantID: user.RestaurantID,
+				UserID:       savedUser.ID,
+				StaffRole:    principal.StaffRoleStaff,
+			}); err != nil {
+				return fmt.Errorf("add restaurant staff: %w", err)
+			}
+			return nil
+		})
+		if errors.Is(err, errEmailTaken) {
+			response.Error(log, w, r, err.Error(), "email taken", http.StatusConflict)
+			return
+		}
+		if err != nil {
+			response.Error(log, w, r, "failed to add staff", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("staff member added", slog.Int("user_id", int(savedUser.ID)))
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, staffStruct.Member{
+			UserID:  savedUser.ID,
+			Email:   savedUser.Email,
+			Name:    savedUser.UserName.String,
+			Phone:   savedUser.Phone,
+			Role:    principal.StaffRoleStaff,
+			AddedAt: savedUser.CreatedAt.Format(time.RFC3339),
+		})
+	}
+}
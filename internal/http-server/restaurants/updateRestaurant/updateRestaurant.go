@@ -0,0 +1,143 @@
+package updateRestaurant
+
+import (
+	"context"
+	"database/sql"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/validation/phoneValidation"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Request holds only the fields to change, omitted fields keep their current value.
+type Request struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=1" example:"Mac"`
+	Description *string `json:"description,omitempty" example:"burgers and fries"`
+	Cuisine     *string `json:"cuisine,omitempty" example:"american"`
+	LogoURL     *string `json:"logo_url,omitempty" validate:"omitempty,url" example:"https://example.com/logo.png"`
+	Address     *string `json:"address,omitempty" validate:"omitempty,min=1" example:"123 street 1"`
+	Phone       *string `json:"phone,omitempty" example:"89055463333"`
+	Timezone    *string `json:"timezone,omitempty" example:"Europe/Moscow"`
+	Paused      *bool   `json:"paused,omitempty" example:"false"`
+}
+
+type Response struct {
+	RestaurantID int32  `json:"restaurant_id" example:"1"`
+	Name         string `json:"name" example:"Mac"`
+	Description  string `json:"description,omitempty" example:"burgers and fries"`
+	Cuisine      string `json:"cuisine,omitempty" example:"american"`
+	LogoURL      string `json:"logo_url,omitempty" example:"https://example.com/logo.png"`
+	Address      string `json:"address" example:"123 street 1"`
+	Phone        string `json:"phone" example:"89055463333"`
+	Timezone     string `json:"timezone" example:"Europe/Moscow"`
+	Paused       bool   `json:"paused" example:"false"`
+}
+
+type restaurantUpdater interface {
+	UpdateRestaurant(ctx context.Context, arg database.UpdateRestaurantParams) (database.Restaurant, error)
+}
+
+// Restaurants godoc
+// @Summary Изменение профиля ресторана
+// @Description Изменяет название, описание, кухню, логотип, адрес, телефон, часовой пояс ресторана, в котором работает пользователь, или приостанавливает прием заказов. Не переданные поля не меняются
+// @Tags Restaurants
+// @Accept json
+// @Produce json
+// @Param request body updateRestaurant.Request true "Поля для изменения"
+// @Success 200 {object} updateRestaurant.Response "Профиль изменен"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 401 {object} response.Response "Неавторизован"
+// @Failure 403 {object} response.Response "Доступ запрещен"
+// @Failure 500 {object} response.Response "Серверная Ошибка"
+// @Router /restaurants/me [patch]
+// @Security BearerAuth
+func New(log *slog.Logger, updater restaurantUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http.restaurants.updateRestaurant"
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		user, ok := principal.FromContext(r.Context())
+		if !ok {
+			response.Error(log, w, r, "Not authorized", "no principal in context", http.StatusUnauthorized)
+			return
+		}
+
+		var req Request
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			response.Error(log, w, r, "failed to decode request", "failed to decode JSON", http.StatusBadRequest)
+			return
+		}
+
+		if err := validator.New().Struct(req); err != nil {
+			if validationErrors, ok := err.(validator.ValidationErrors); ok {
+				response.ValidationError(log, w, r, validationErrors)
+			} else {
+				response.Error(log, w, r, "failed to validate", "failed to validate JSON", http.StatusBadRequest)
+			}
+			return
+		}
+
+		if req.Phone != nil && !phoneValidation.IsValidRuPhoneNumber(*req.Phone) {
+			response.Error(log, w, r, "failed to validate phone", "invalid phone", http.StatusBadRequest)
+			return
+		}
+
+		if req.Timezone != nil {
+			if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "" {
+				response.Error(log, w, r, "unknown timezone", "invalid timezone", http.StatusBadRequest)
+				return
+			}
+		}
+
+		paused := sql.NullBool{}
+		if req.Paused != nil {
+			paused = sql.NullBool{Bool: *req.Paused, Valid: true}
+		}
+
+		restaurant, err := updater.UpdateRestaurant(r.Context(), database.UpdateRestaurantParams{
+			Name:        nullString(req.Name),
+			Description: nullString(req.Description),
+			Cuisine:     nullString(req.Cuisine),
+			LogoUrl:     nullString(req.LogoURL),
+			Address:     nullString(req.Address),
+			Phone:       nullString(req.Phone),
+			Timezone:    nullString(req.Timezone),
+			Paused:      paused,
+			ID:          user.RestaurantID,
+		})
+		if err != nil {
+			response.Error(log, w, r, "failed to update", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		log.Info("restaurant profile updated", slog.Int("restaurant_id", int(restaurant.ID)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			RestaurantID: restaurant.ID,
+			Name:         restaurant.Name,
+			Description:  restaurant.Description.String,
+			Cuisine:      restaurant.Cuisine.String,
+			LogoURL:      restaurant.LogoUrl.String,
+			Address:      restaurant.Address,
+			Phone:        restaurant.Phone,
+			Timezone:     restaurant.Timezone,
+			Paused:       restaurant.Paused,
+		})
+	}
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
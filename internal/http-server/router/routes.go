@@ -2,57 +2,224 @@ package router
 
 import (
 	"github.com/go-chi/chi/v5"
-	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/courierHub"
+	"github.com/yourgfslove/GodFoodApi/internal/dispatch"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/orderStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/events"
+	"github.com/yourgfslove/GodFoodApi/internal/geocode"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/addresses/deleteAddress"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/addresses/getAddresses"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/addresses/newAddress"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/addresses/setDefaultAddress"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/addresses/updateAddress"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/auth/login"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/auth/logout"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/auth/logoutAll"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/auth/refresh"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/auth/register"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/auth/revokeSession"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/auth/sessions"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/couriers/getProfile"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/couriers/offersSocket"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/couriers/postLocation"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/couriers/setStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/middleware/courierOnline"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/middleware/idempotency"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/middleware/middlewareJWT"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/middleware/restaurantStaff"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/cancelOrder"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/courier/acceptOffer"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/courier/declineOffer"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/courier/getCurrentOrder"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/courier/getOffer"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/courier/getPendingOrders"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/courier/orderAssign"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/courier/orderDelivered"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/courier/orderDrop"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/courier/orderPickup"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/getOrderByID"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/getOrdersForUser"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/getTracking"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/orderEvents"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/placeorder"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/restaurant/cancelAcceptedOrder"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/restaurant/rejectOrder"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/orders/restaurant/updateOrderStatus"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/GetRestaurants"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/deliveryZones/deleteDeliveryZone"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/deliveryZones/getDeliveryZones"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/deliveryZones/newDeliveryZone"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/getRestaurantByID"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/deleteCategory"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/deleteMenuItem"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/deleteOptionGroup"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/getMenu"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/newCategory"
 	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/newMenuItem"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/newOptionGroup"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/setAvailability"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/updateCategory"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/updateMenuItem"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/updateOption"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/restaurantEvents"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/setOpeningHours"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/staff/deleteStaff"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/staff/getStaff"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/staff/newStaff"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/updateRestaurant"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/search"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/auth/principal"
+	"github.com/yourgfslove/GodFoodApi/internal/storage"
 	"log/slog"
 )
 
 type Deps struct {
-	Storage *database.Queries
-	Logger  *slog.Logger
-	Cfg     struct {
+	Storage    *storage.Storage
+	Logger     *slog.Logger
+	Dispatcher *dispatch.Dispatcher
+	Events     *events.Bus
+	CourierHub *courierHub.Hub
+	Geocoder   geocode.Geocoder
+	Cfg        struct {
 		SecretJWT string
 	}
 }
 
 func SetupRoutes(r *chi.Mux, deps *Deps) {
+	authJWT := middlewareJWT.AuthJWTMiddleware(deps.Logger, deps.Cfg.SecretJWT)
+	onlyCustomer := middlewareJWT.RequireRole(deps.Logger, principal.RoleCustomer)
+	onlyCourier := middlewareJWT.RequireRole(deps.Logger, principal.RoleCourier)
+	onlyRestaurant := middlewareJWT.RequireRole(deps.Logger, principal.RoleRestaurant)
+	restaurantStaffOnly := restaurantStaff.New(deps.Logger, deps.Storage)
+	restaurantOwnerOnly := restaurantStaff.RequireOwner(deps.Logger)
+	onlineCourier := courierOnline.New(deps.Logger, deps.Storage)
+	idempotent := idempotency.New(deps.Logger, deps.Storage)
+
 	r.Post("/register", register.New(deps.Logger, deps.Storage, deps.Storage, deps.Cfg.SecretJWT))
 	r.Post("/login", login.New(deps.Logger, deps.Storage, deps.Storage, deps.Cfg.SecretJWT))
-	r.With(middlewareJWT.AuthJWTMiddleware(deps.Logger, deps.Cfg.SecretJWT)).
-		Post("/restaurants/menuItems", newMenuItem.New(deps.Logger, deps.Storage, deps.Storage))
-	r.Get("/restaurants/{id}/menuItems", getMenu.New(deps.Logger, deps.Storage))
-	r.Get("/restaurants", GetRestaurants.New(deps.Logger, deps.Storage))
-	r.Get("/restaurants/{id}", getRestaurantByID.New(deps.Logger, deps.Storage))
-	r.With(middlewareJWT.AuthJWTMiddleware(deps.Logger, deps.Cfg.SecretJWT)).
-		Post("/orders", placeorder.New(deps.Logger, deps.Storage, deps.Storage, deps.Storage, deps.Storage))
-	r.With(middlewareJWT.AuthJWTMiddleware(deps.Logger, deps.Cfg.SecretJWT)).
-		Get("/orders", getOrdersForUser.New(deps.Logger, deps.Storage))
-	r.With(middlewareJWT.AuthJWTMiddleware(deps.Logger, deps.Cfg.SecretJWT)).
-		Get("/orders/{id}", getOrderByID.New(deps.Logger, deps.Storage))
-	r.With(middlewareJWT.AuthJWTMiddleware(deps.Logger, deps.Cfg.SecretJWT)).
+	r.Post("/refresh", refresh.New(deps.Logger, deps.Storage, deps.Storage, deps.Storage, deps.Cfg.SecretJWT))
+	r.With(authJWT).
+		Post("/logout", logout.New(deps.Logger, deps.Storage))
+	r.With(authJWT).
+		Post("/logout/all", logoutAll.New(deps.Logger, deps.Storage))
+	r.With(authJWT).
+		Get("/sessions", sessions.New(deps.Logger, deps.Storage))
+	r.With(authJWT).
+		Delete("/sessions/{id}", revokeSession.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyCustomer).
+		Get("/users/me/addresses", getAddresses.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyCustomer).
+		Post("/users/me/addresses", newAddress.New(deps.Logger, deps.Storage, deps.Geocoder))
+	r.With(authJWT, onlyCustomer).
+		Patch("/users/me/addresses/{id}", updateAddress.New(deps.Logger, deps.Storage, deps.Geocoder))
+	r.With(authJWT, onlyCustomer).
+		Delete("/users/me/addresses/{id}", deleteAddress.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyCustomer).
+		Patch("/users/me/addresses/{id}/default", setDefaultAddress.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Post("/restaurants/menuItems", newMenuItem.New(deps.Logger, deps.Storage, deps.Storage, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Patch("/restaurants/menuItems/availability", setAvailability.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Patch("/restaurants/menuItems/{id}", updateMenuItem.New(deps.Logger, deps.Storage, deps.Storage, deps.Storage, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Delete("/restaurants/menuItems/{id}", deleteMenuItem.New(deps.Logger, deps.Storage, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Post("/restaurants/menuItems/{id}/optionGroups", newOptionGroup.New(deps.Logger, deps.Storage, deps.Storage, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Delete("/restaurants/optionGroups/{id}", deleteOptionGroup.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Patch("/restaurants/options/{id}", updateOption.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Post("/restaurants/categories", newCategory.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Patch("/restaurants/categories/{id}", updateCategory.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Delete("/restaurants/categories/{id}", deleteCategory.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Patch("/restaurants/me", updateRestaurant.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Put("/restaurants/me/hours", setOpeningHours.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Get("/restaurants/me/events", restaurantEvents.New(deps.Logger, deps.Storage, deps.Events))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Get("/restaurants/me/staff", getStaff.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly, restaurantOwnerOnly).
+		Post("/restaurants/me/staff", newStaff.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly, restaurantOwnerOnly).
+		Delete("/restaurants/me/staff/{id}", deleteStaff.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Post("/restaurants/me/zones", newDeliveryZone.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Delete("/restaurants/me/zones/{id}", deleteDeliveryZone.New(deps.Logger, deps.Storage))
+	r.Get("/restaurants/{id}/zones", getDeliveryZones.New(deps.Logger, deps.Storage))
+	r.Get("/restaurants/{id}/menuItems", getMenu.New(deps.Logger, deps.Storage, deps.Storage, deps.Storage, deps.Storage))
+	r.Get("/search", search.New(deps.Logger, deps.Storage))
+	r.Get("/restaurants", GetRestaurants.New(deps.Logger, deps.Storage, deps.Storage))
+	r.Get("/restaurants/{id}", getRestaurantByID.New(deps.Logger, deps.Storage, deps.Storage, deps.Storage))
+	r.With(authJWT, onlyCustomer, idempotent).
+		Post("/orders", placeorder.New(
+			deps.Logger,
+			deps.Storage,
+			deps.Storage,
+			deps.Storage,
+			deps.Geocoder,
+			deps.Storage,
+			deps.Storage,
+			deps.Storage,
+			deps.Storage,
+			deps.Events))
+	r.With(authJWT).
+		Get("/orders", getOrdersForUser.New(deps.Logger, deps.Storage, deps.Storage))
+	r.With(authJWT).
+		Get("/orders/{id}", getOrderByID.New(deps.Logger, deps.Storage, deps.Storage, deps.Storage))
+	r.With(authJWT).
+		Get("/orders/{id}/events", orderEvents.New(deps.Logger, deps.Storage, deps.Storage, deps.Events))
+	r.With(authJWT, onlyCustomer).
+		Get("/orders/{id}/tracking", getTracking.New(deps.Logger, deps.Storage, deps.Storage))
+	r.With(authJWT, onlyCourier, onlineCourier).
 		Get("/orders/pending", getPendingOrders.New(deps.Logger, deps.Storage, deps.Storage))
-	r.With(middlewareJWT.AuthJWTMiddleware(deps.Logger, deps.Cfg.SecretJWT)).
+	r.With(authJWT, onlyCourier, onlineCourier).
 		Patch("/orders/{id}/assign", orderAssign.New(
 			deps.Logger,
 			deps.Storage,
 			deps.Storage,
 			deps.Storage,
-			deps.Storage))
-	r.With(middlewareJWT.AuthJWTMiddleware(deps.Logger, deps.Cfg.SecretJWT)).
+			deps.Storage,
+			deps.Dispatcher,
+			deps.Events))
+	r.With(authJWT, onlyCustomer).
+		Patch("/orders/{id}/cancel", cancelOrder.New(deps.Logger, deps.Storage, deps.Storage, deps.Events))
+	r.With(authJWT, onlyCourier).
+		Patch("/orders/{id}/drop", orderDrop.New(deps.Logger, deps.Storage, deps.Storage, deps.Dispatcher, deps.Events))
+	r.With(authJWT, onlyCourier).
+		Patch("/orders/{id}/pickup", orderPickup.New(deps.Logger, deps.Storage, deps.Storage, deps.Events))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Patch("/orders/{id}/accept", updateOrderStatus.New(deps.Logger, deps.Storage, deps.Storage, deps.Dispatcher, deps.Events, orderStatus.Accepted))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Patch("/orders/{id}/reject", rejectOrder.New(deps.Logger, deps.Storage, deps.Storage, deps.Events))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Patch("/restaurants/me/orders/{id}/cancel", cancelAcceptedOrder.New(deps.Logger, deps.Storage, deps.Storage, deps.Events))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Patch("/orders/{id}/preparing", updateOrderStatus.New(deps.Logger, deps.Storage, deps.Storage, deps.Dispatcher, deps.Events, orderStatus.Preparing))
+	r.With(authJWT, onlyRestaurant, restaurantStaffOnly).
+		Patch("/orders/{id}/ready", updateOrderStatus.New(deps.Logger, deps.Storage, deps.Storage, deps.Dispatcher, deps.Events, orderStatus.ReadyForPickup))
+	r.With(authJWT, onlyCourier).
+		Get("/orders/offer", getOffer.New(deps.Logger, deps.Storage, deps.Storage))
+	r.With(authJWT, onlyCourier, onlineCourier).
+		Patch("/orders/offer/{id}/accept", acceptOffer.New(deps.Logger, deps.Storage, deps.Storage, deps.Dispatcher, deps.Events))
+	r.With(authJWT, onlyCourier).
+		Patch("/orders/offer/{id}/decline", declineOffer.New(deps.Logger, deps.Storage, deps.Dispatcher))
+	r.With(authJWT, onlyCourier).
 		Get("/orders/current", getCurrentOrder.New(deps.Logger, deps.Storage, deps.Storage))
-	r.With(middlewareJWT.AuthJWTMiddleware(deps.Logger, deps.Cfg.SecretJWT)).
-		Patch("/orders/delivered", orderDelivered.New(deps.Logger, deps.Storage, deps.Storage, deps.Storage))
+	r.With(authJWT, onlyCourier).
+		Patch("/orders/delivered", orderDelivered.New(deps.Logger, deps.Storage, deps.Storage, deps.Events))
+	r.With(authJWT, onlyCourier).
+		Get("/couriers/me", getProfile.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyCourier).
+		Patch("/couriers/me/status", setStatus.New(deps.Logger, deps.Storage, deps.Storage, deps.Storage, deps.Storage, deps.Dispatcher))
+	r.With(authJWT, onlyCourier, onlineCourier).
+		Post("/couriers/me/location", postLocation.New(deps.Logger, deps.Storage))
+	r.With(authJWT, onlyCourier).
+		Get("/couriers/me/offers/ws", offersSocket.New(deps.Logger, deps.CourierHub))
 }
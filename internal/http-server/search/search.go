@@ -0,0 +1,169 @@
+package search
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/api/response"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 50
+	// dishesPerRestaurant caps matching dishes per restaurant to keep popular words from flooding the response
+	dishesPerRestaurant = 5
+)
+
+type searcher interface {
+	SearchRestaurants(ctx context.Context, arg database.SearchRestaurantsParams) ([]database.SearchRestaurantsRow, error)
+	SearchMenuItems(ctx context.Context, arg database.SearchMenuItemsParams) ([]database.SearchMenuItemsRow, error)
+}
+
+type Response struct {
+	Query       string       `json:"query" example:"бургер"`
+	Restaurants []Restaurant `json:"restaurants"`
+}
+
+// Restaurant is a search hit: the restaurant itself matched, one of its dishes did, or both.
+type Restaurant struct {
+	RestaurantID int32   `json:"restaurant_id" example:"1"`
+	Name         string  `json:"name" example:"Mac"`
+	Description  string  `json:"description,omitempty" example:"burgers and fries"`
+	Cuisine      string  `json:"cuisine,omitempty" example:"american"`
+	LogoURL      string  `json:"logo_url,omitempty" example:"https://example.com/logo.png"`
+	Rank         float32 `json:"rank" example:"0.6"`
+	Dishes       []Dish  `json:"dishes"`
+}
+
+type Dish struct {
+	ID          int32       `json:"id" example:"6"`
+	Name        string      `json:"name" example:"Cheeseburger"`
+	Description string      `json:"description,omitempty" example:"burger with cheese"`
+	Price       money.Money `json:"price"`
+	Rank        float32     `json:"rank" example:"0.6"`
+}
+
+// Search godoc
+// @Summary Поиск ресторанов и блюд
+// @Description Полнотекстовый поиск по названию и описанию ресторанов и доступных блюд (русский и английский). Рестораны отсортированы по релевантности, в каждом перечислены подходящие блюда
+// @Tags Search
+// @Produce json
+// @Param q query string true "Поисковый запрос"
+// @Param limit query int false "Максимум ресторанов (1-50, по умолчанию 20)"
+// @Success 200 {object} search.Response "Результаты поиска"
+// @Failure 400 {object} response.Response "Некорректные данные"
+// @Failure 500 {object} response.Response "Ошибка сервера"
+// @Router /search [get]
+func New(log *slog.Logger, searcher searcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "http-server.search"
+		log = log.With(slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		query := strings.TrimSpace(r.URL.Query().Get("q"))
+		if query == "" {
+			response.Error(log, w, r, "q is required", "empty search query", http.StatusBadRequest)
+			return
+		}
+
+		limit := int32(defaultLimit)
+		if raw := r.URL.Query().Get("limit"); raw != "" {
+			parsed, err := strconv.ParseInt(raw, 10, 32)
+			if err != nil || parsed < 1 || parsed > maxLimit {
+				response.Error(log, w, r, "limit must be between 1 and 50", "invalid limit", http.StatusBadRequest)
+				return
+			}
+			limit = int32(parsed)
+		}
+
+		restaurants, err := searcher.SearchRestaurants(r.Context(), database.SearchRestaurantsParams{
+			Query:       query,
+			ResultLimit: limit,
+		})
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+		dishes, err := searcher.SearchMenuItems(r.Context(), database.SearchMenuItemsParams{
+			Query:       query,
+			ResultLimit: limit * dishesPerRestaurant,
+		})
+		if err != nil {
+			response.Error(log, w, r, "something went wrong", sl.Err(err).String(), http.StatusInternalServerError)
+			return
+		}
+
+		results := group(restaurants, dishes)
+		if len(results) > int(limit) {
+			results = results[:limit]
+		}
+
+		log.Info("search done", slog.Int("restaurants", len(results)))
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, Response{
+			Query:       query,
+			Restaurants: results,
+		})
+	}
+}
+
+// group merges restaurant and dish hits by restaurant. A restaurant ranks by its best hit,
+// dishes keep the relevance order of the query.
+func group(restaurants []database.SearchRestaurantsRow, dishes []database.SearchMenuItemsRow) []Restaurant {
+	results := make([]Restaurant, 0, len(restaurants))
+	index := make(map[int32]int, len(restaurants))
+	for _, r := range restaurants {
+		index[r.ID] = len(results)
+		results = append(results, Restaurant{
+			RestaurantID: r.ID,
+			Name:         r.Name,
+			Description:  r.Description.String,
+			Cuisine:      r.Cuisine.String,
+			LogoURL:      r.LogoUrl.String,
+			Rank:         r.Rank,
+			Dishes:       []Dish{},
+		})
+	}
+
+	for _, d := range dishes {
+		i, ok := index[d.RestaurantID]
+		if !ok {
+			i = len(results)
+			index[d.RestaurantID] = i
+			results = append(results, Restaurant{
+				RestaurantID: d.RestaurantID,
+				Name:         d.RestaurantName,
+				Description:  d.RestaurantDescription.String,
+				Cuisine:      d.RestaurantCuisine.String,
+				LogoURL:      d.RestaurantLogoUrl.String,
+				Dishes:       []Dish{},
+			})
+		}
+		if len(results[i].Dishes) >= dishesPerRestaurant {
+			continue
+		}
+		results[i].Dishes = append(results[i].Dishes, Dish{
+			ID:          d.ID,
+			Name:        d.Name,
+			Description: d.Description.String,
+			Price:       money.New(d.Price, d.Currency),
+			Rank:        d.Rank,
+		})
+		if d.Rank > results[i].Rank {
+			results[i].Rank = d.Rank
+		}
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		return results[a].Rank > results[b].Rank
+	})
+	return results
+}
@@ -0,0 +1,55 @@
+package addressStruct
+
+import (
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/address"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+)
+
+// Address is a saved address of the address book.
+type Address struct {
+	ID        int32     `json:"id" example:"3"`
+	Street    string    `json:"street" example:"Tverskaya"`
+	Building  string    `json:"building" example:"7"`
+	Apartment string    `json:"apartment" example:"12"`
+	Entrance  string    `json:"entrance" example:"2"`
+	Floor     string    `json:"floor" example:"5"`
+	Intercom  string    `json:"intercom" example:"12K"`
+	Comment   string    `json:"comment" example:"call on arrival"`
+	Location  geo.Point `json:"location"`
+	// LocatedExactly is false for a made up point, which delivery zones are not checked against.
+	LocatedExactly bool `json:"located_exactly" example:"true"`
+	IsDefault      bool `json:"is_default" example:"true"`
+	// Formatted is the one line form stored on orders.
+	Formatted string `json:"formatted" example:"Tverskaya, 7, apt 12, entrance 2, floor 5, intercom 12K (call on arrival)"`
+}
+
+// Parts returns the address of a stored row.
+func Parts(row database.Address) address.Address {
+	return address.Address{
+		Street:    row.Street,
+		Building:  row.Building,
+		Apartment: row.Apartment,
+		Entrance:  row.Entrance,
+		Floor:     row.Floor,
+		Intercom:  row.Intercom,
+		Comment:   row.Comment,
+	}
+}
+
+func FromRow(row database.Address) Address {
+	return Address{
+		ID:             row.ID,
+		Street:         row.Street,
+		Building:       row.Building,
+		Apartment:      row.Apartment,
+		Entrance:       row.Entrance,
+		Floor:          row.Floor,
+		Intercom:       row.Intercom,
+		Comment:        row.Comment,
+		Location:       geo.Point{Lat: row.Lat, Lng: row.Lng},
+		LocatedExactly: row.LocatedExactly,
+		IsDefault:      row.IsDefault,
+		Formatted:      Parts(row).String(),
+	}
+}
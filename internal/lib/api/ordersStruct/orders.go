@@ -2,102 +2,142 @@ package ordersStruct
 
 import (
 	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
 	"time"
 )
 
 type Order struct {
-	RestaurantName    string  `json:"restaurant_Name" example:"Mac"`
-	RestaurantAddress string  `json:"restaurant_Address" example:"123 address"`
-	RestaurantPhone   string  `json:"restaurant_Phone" example:"89056666666"`
-	DeliveryAddress   string  `json:"delivery_Address" example:"1223 address"`
-	UserName          string  `json:"user_name" example:"Ivan"`
-	Status            string  `json:"status" example:"pending"`
-	TotalPrice        float64 `json:"total_price" example:"300.0"`
-	CreatedAt         string  `json:"created_at" example:"2013-08-20T18:08:41+00:00"`
-	Items             []Item  `json:"items"`
+	OrderID           int32       `json:"order_id" example:"1"`
+	RestaurantName    string      `json:"restaurant_Name" example:"Mac"`
+	RestaurantAddress string      `json:"restaurant_Address" example:"123 address"`
+	RestaurantPhone   string      `json:"restaurant_Phone" example:"89056666666"`
+	DeliveryAddress   string      `json:"delivery_Address" example:"1223 address"`
+	UserName          string      `json:"user_name" example:"Ivan"`
+	Status            string      `json:"status" example:"pending"`
+	TotalPrice        money.Money `json:"total_price"`
+	CreatedAt         string      `json:"created_at" example:"2013-08-20T18:08:41+00:00"`
+	Items             []Item      `json:"items"`
 }
 
 type OrderForCourier struct {
-	OrderID           int32   `json:"order_id" example:"1"`
-	RestaurantName    string  `json:"restaurant_Name" example:"Mac"`
-	RestaurantAddress string  `json:"restaurant_Address" example:"123 address"`
-	RestaurantPhone   string  `json:"restaurant_Phone" example:"89056666666"`
-	DeliveryAddress   string  `json:"delivery_Address" example:"1223 address"`
-	UserPhone         string  `json:"user_phone" example:"Ivan"`
-	Reward            float64 `json:"reward" example:"30.0"`
-	CreatedAt         string  `json:"created_at" example:"2013-08-20T18:08:41+00:00"`
-	Items             []Item  `json:"items"`
+	OrderID           int32       `json:"order_id" example:"1"`
+	RestaurantName    string      `json:"restaurant_Name" example:"Mac"`
+	RestaurantAddress string      `json:"restaurant_Address" example:"123 address"`
+	RestaurantPhone   string      `json:"restaurant_Phone" example:"89056666666"`
+	DeliveryAddress   string      `json:"delivery_Address" example:"1223 address"`
+	UserPhone         string      `json:"user_phone" example:"Ivan"`
+	Status            string      `json:"status" example:"accepted"`
+	Reward            money.Money `json:"reward"`
+	CreatedAt         string      `json:"created_at" example:"2013-08-20T18:08:41+00:00"`
+	Items             []Item      `json:"items"`
 }
 
 type Item struct {
-	MenuItemID int32   `json:"menu_item_id" example:"1"`
-	ItemName   string  `json:"item_name" example:"Burger with cheese"`
-	ItemPrice  float64 `json:"item_price" example:"100.0"`
-	Quantity   int32   `json:"quantity" example:"3"`
+	MenuItemID int32       `json:"menu_item_id" example:"1"`
+	ItemName   string      `json:"item_name" example:"Burger with cheese"`
+	ItemPrice  money.Money `json:"item_price"`
+	Quantity   int32       `json:"quantity" example:"3"`
+	Options    []Option    `json:"options"`
+	Total      money.Money `json:"total"`
 }
 
-func MakeOrders(rows []database.GetFullOrdersByUserIDRow) []Order {
-	ordersMap := make(map[int32]*Order, len(rows))
+// Option is a modifier chosen for an order item, snapshotted at placement like the item price.
+type Option struct {
+	Group      string      `json:"group" example:"Size"`
+	Name       string      `json:"name" example:"L"`
+	PriceDelta money.Money `json:"price_delta"`
+}
+
+// OptionsByItem groups option snapshots by order item id.
+func OptionsByItem(rows []database.OrderitemOption) map[int32][]Option {
+	options := make(map[int32][]Option)
+	for _, row := range rows {
+		options[row.OrderitemID] = append(options[row.OrderitemID], Option{
+			Group:      row.GroupName,
+			Name:       row.OptionName,
+			PriceDelta: money.New(row.PriceDelta, row.Currency),
+		})
+	}
+	return options
+}
+
+// ItemOptions returns the options of one order item, never nil so it encodes as an empty list.
+func ItemOptions(options map[int32][]Option, orderItemID int32) []Option {
+	if o, ok := options[orderItemID]; ok {
+		return o
+	}
+	return []Option{}
+}
+
+// ItemTotal is the line total of an order item: the unit price with its options times the quantity.
+func ItemTotal(price, optionsPrice int64, quantity int32, currency string) money.Money {
+	return money.New(price+optionsPrice, currency).Mul(int64(quantity))
+}
+
+// MakeOrders groups item rows into orders keeping the order of the rows, so the newest-first sort of the query survives.
+func MakeOrders(rows []database.GetFullOrdersByUserIDRow, options map[int32][]Option) []Order {
+	orders := make([]Order, 0)
+	index := make(map[int32]int)
 	for _, row := range rows {
-		order, exists := ordersMap[row.OrderID]
+		i, exists := index[row.OrderID]
 		if !exists {
-			order = &Order{
-				RestaurantName:    row.RestaurantName.String,
-				RestaurantAddress: row.RestaurantAddress.String,
+			i = len(orders)
+			index[row.OrderID] = i
+			orders = append(orders, Order{
+				OrderID:           row.OrderID,
+				RestaurantName:    row.RestaurantName,
+				RestaurantAddress: row.RestaurantAddress,
 				RestaurantPhone:   row.RestaurantPhone,
 				DeliveryAddress:   row.DeliveryAddress,
 				UserName:          row.CostomerName.String,
 				Status:            row.Status,
-				TotalPrice:        0,
+				TotalPrice:        money.New(row.Total, row.Currency),
 				CreatedAt:         row.CreatedAt.Time.Format(time.RFC1123),
 				Items:             []Item{},
-			}
-			ordersMap[row.OrderID] = order
+			})
 		}
-		order.Items = append(order.Items, Item{
+		orders[i].Items = append(orders[i].Items, Item{
 			MenuItemID: row.MenuItemID,
 			ItemName:   row.MenuItemName,
-			ItemPrice:  row.Price,
+			ItemPrice:  money.New(row.Price, row.Currency),
 			Quantity:   row.Quanity,
+			Options:    ItemOptions(options, row.OrderItemID),
+			Total:      ItemTotal(row.Price, row.OptionsPrice, row.Quanity, row.Currency),
 		})
-		order.TotalPrice += row.Price * float64(row.Quanity)
-	}
-	var orders []Order
-	for _, order := range ordersMap {
-		orders = append(orders, *order)
 	}
 	return orders
 }
 
-func MakePendingOrders(rows []database.GetFullPendingOrdersRow) []OrderForCourier {
-	ordersMap := make(map[int32]*OrderForCourier, len(rows))
+// MakePendingOrders is MakeOrders for the courier view of orders.
+func MakePendingOrders(rows []database.GetFullPendingOrdersRow, options map[int32][]Option) []OrderForCourier {
+	orders := make([]OrderForCourier, 0)
+	index := make(map[int32]int)
 	for _, row := range rows {
-		order, exists := ordersMap[row.OrderID]
+		i, exists := index[row.OrderID]
 		if !exists {
-			order = &OrderForCourier{
+			i = len(orders)
+			index[row.OrderID] = i
+			orders = append(orders, OrderForCourier{
 				OrderID:           row.OrderID,
-				RestaurantName:    row.RestaurantName.String,
-				RestaurantAddress: row.RestaurantAddress.String,
+				RestaurantName:    row.RestaurantName,
+				RestaurantAddress: row.RestaurantAddress,
 				RestaurantPhone:   row.RestaurantPhone,
 				DeliveryAddress:   row.DeliveryAddress,
 				UserPhone:         row.CustomerPhone,
-				Reward:            0,
+				Status:            row.Status,
+				Reward:            money.New(row.Total, row.Currency).Percent(5),
 				CreatedAt:         row.CreatedAt.Time.Format(time.RFC1123),
 				Items:             []Item{},
-			}
-			ordersMap[row.OrderID] = order
+			})
 		}
-		order.Items = append(order.Items, Item{
+		orders[i].Items = append(orders[i].Items, Item{
 			MenuItemID: row.MenuItemID,
 			ItemName:   row.MenuItemName,
-			ItemPrice:  row.Price,
+			ItemPrice:  money.New(row.Price, row.Currency),
 			Quantity:   row.Quanity,
+			Options:    ItemOptions(options, row.OrderItemID),
+			Total:      ItemTotal(row.Price, row.OptionsPrice, row.Quanity, row.Currency),
 		})
-		order.Reward += row.Price * float64(row.Quanity) * 0.05
-	}
-	var orders []OrderForCourier
-	for _, order := range ordersMap {
-		orders = append(orders, *order)
 	}
 	return orders
 }
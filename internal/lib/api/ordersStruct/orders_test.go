@@ -0,0 +1,215 @@
+package ordersStruct
+
+import (
+	"database/sql"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var createdAt = sql.NullTime{Time: time.Date(2025, 6, 17, 12, 0, 0, 0, time.UTC), Valid: true}
+
+func userRow(orderID, orderItemID int32, name string, price, optionsPrice int64, quantity int32) database.GetFullOrdersByUserIDRow {
+	return database.GetFullOrdersByUserIDRow{
+		OrderID:      orderID,
+		Status:       "pending",
+		CreatedAt:    createdAt,
+		Total:        1000,
+		Currency:     "RUB",
+		MenuItemName: name,
+		Quanity:      quantity,
+		Price:        price,
+		OrderItemID:  orderItemID,
+		OptionsPrice: optionsPrice,
+	}
+}
+
+func pendingRow(orderID, orderItemID int32, name string) database.GetFullPendingOrdersRow {
+	return database.GetFullPendingOrdersRow{
+		OrderID:      orderID,
+		Status:       "accepted",
+		CreatedAt:    createdAt,
+		Total:        1000,
+		Currency:     "RUB",
+		MenuItemName: name,
+		Quanity:      1,
+		Price:        100,
+		OrderItemID:  orderItemID,
+	}
+}
+
+func orderIDs(orders []Order) []int32 {
+	ids := make([]int32, 0, len(orders))
+	for _, o := range orders {
+		ids = append(ids, o.OrderID)
+	}
+	return ids
+}
+
+func itemNames(items []Item) []string {
+	names := make([]string, 0, len(items))
+	for _, i := range items {
+		names = append(names, i.ItemName)
+	}
+	return names
+}
+
+func TestMakeOrders(t *testing.T) {
+	testcases := []struct {
+		name      string
+		rows      []database.GetFullOrdersByUserIDRow
+		wantIDs   []int32
+		wantItems map[int32][]string
+	}{
+		{
+			name:      "no rows",
+			rows:      nil,
+			wantIDs:   []int32{},
+			wantItems: map[int32][]string{},
+		},
+		{
+			name: "keeps newest first order of the query",
+			rows: []database.GetFullOrdersByUserIDRow{
+				userRow(9, 30, "burger", 100, 0, 1),
+				userRow(7, 20, "fries", 100, 0, 1),
+				userRow(3, 10, "cola", 100, 0, 1),
+			},
+			wantIDs: []int32{9, 7, 3},
+			wantItems: map[int32][]string{
+				9: {"burger"},
+				7: {"fries"},
+				3: {"cola"},
+			},
+		},
+		{
+			name: "groups items of one order in row order",
+			rows: []database.GetFullOrdersByUserIDRow{
+				userRow(5, 11, "burger", 100, 0, 1),
+				userRow(5, 12, "fries", 100, 0, 1),
+				userRow(5, 13, "cola", 100, 0, 1),
+				userRow(2, 4, "soup", 100, 0, 1),
+			},
+			wantIDs: []int32{5, 2},
+			wantItems: map[int32][]string{
+				5: {"burger", "fries", "cola"},
+				2: {"soup"},
+			},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			// the result must not depend on map iteration, so every case runs several times
+			for run := 0; run < 20; run++ {
+				orders := MakeOrders(testcase.rows, nil)
+				if got := orderIDs(orders); !reflect.DeepEqual(got, testcase.wantIDs) {
+					t.Fatalf("order ids = %v, want %v", got, testcase.wantIDs)
+				}
+				for _, o := range orders {
+					if got := itemNames(o.Items); !reflect.DeepEqual(got, testcase.wantItems[o.OrderID]) {
+						t.Fatalf("order %d items = %v, want %v", o.OrderID, got, testcase.wantItems[o.OrderID])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMakeOrdersItemTotals(t *testing.T) {
+	testcases := []struct {
+		name         string
+		price        int64
+		optionsPrice int64
+		quantity     int32
+		want         money.Money
+	}{
+		{name: "plain item", price: 25000, quantity: 1, want: money.New(25000, "RUB")},
+		{name: "quantity", price: 25000, quantity: 3, want: money.New(75000, "RUB")},
+		{name: "options are added per unit", price: 25000, optionsPrice: 5000, quantity: 2, want: money.New(60000, "RUB")},
+		{name: "negative option delta", price: 25000, optionsPrice: -3000, quantity: 1, want: money.New(22000, "RUB")},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			rows := []database.GetFullOrdersByUserIDRow{
+				userRow(1, 1, "burger", testcase.price, testcase.optionsPrice, testcase.quantity),
+			}
+			orders := MakeOrders(rows, nil)
+			if len(orders) != 1 || len(orders[0].Items) != 1 {
+				t.Fatalf("got %d orders, want one order with one item", len(orders))
+			}
+			item := orders[0].Items[0]
+			if item.Total != testcase.want {
+				t.Errorf("total = %v, want %v", item.Total, testcase.want)
+			}
+			if item.ItemPrice != money.New(testcase.price, "RUB") {
+				t.Errorf("item price = %v, want %v", item.ItemPrice, money.New(testcase.price, "RUB"))
+			}
+		})
+	}
+}
+
+func TestMakeOrdersOptions(t *testing.T) {
+	options := OptionsByItem([]database.OrderitemOption{
+		{OrderitemID: 1, GroupName: "Size", OptionName: "L", PriceDelta: 5000, Currency: "RUB"},
+		{OrderitemID: 1, GroupName: "Extras", OptionName: "cheese", PriceDelta: 3000, Currency: "RUB"},
+	})
+	rows := []database.GetFullOrdersByUserIDRow{
+		userRow(1, 1, "burger", 25000, 8000, 1),
+		userRow(1, 2, "cola", 10000, 0, 1),
+	}
+
+	orders := MakeOrders(rows, options)
+
+	want := []Option{
+		{Group: "Size", Name: "L", PriceDelta: money.New(5000, "RUB")},
+		{Group: "Extras", Name: "cheese", PriceDelta: money.New(3000, "RUB")},
+	}
+	if got := orders[0].Items[0].Options; !reflect.DeepEqual(got, want) {
+		t.Errorf("burger options = %v, want %v", got, want)
+	}
+	if got := orders[0].Items[1].Options; got == nil || len(got) != 0 {
+		t.Errorf("cola options = %#v, want empty list", got)
+	}
+}
+
+func TestMakePendingOrders(t *testing.T) {
+	testcases := []struct {
+		name    string
+		rows    []database.GetFullPendingOrdersRow
+		wantIDs []int32
+	}{
+		{
+			name:    "no rows",
+			rows:    nil,
+			wantIDs: []int32{},
+		},
+		{
+			name: "keeps query order",
+			rows: []database.GetFullPendingOrdersRow{
+				pendingRow(12, 5, "burger"),
+				pendingRow(12, 6, "fries"),
+				pendingRow(8, 3, "cola"),
+				pendingRow(4, 1, "soup"),
+			},
+			wantIDs: []int32{12, 8, 4},
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			for run := 0; run < 20; run++ {
+				orders := MakePendingOrders(testcase.rows, nil)
+				got := make([]int32, 0, len(orders))
+				for _, o := range orders {
+					got = append(got, o.OrderID)
+				}
+				if !reflect.DeepEqual(got, testcase.wantIDs) {
+					t.Fatalf("order ids = %v, want %v", got, testcase.wantIDs)
+				}
+			}
+		})
+	}
+}
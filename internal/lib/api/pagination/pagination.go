@@ -0,0 +1,132 @@
+package pagination
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidLimit  = errors.New("invalid limit")
+	ErrInvalidTime   = errors.New("invalid time")
+	ErrInvalidPoint  = errors.New("lat and lng must be numbers and set together")
+)
+
+// Cursor is embedded next to the items field of every paginated response. NextCursor is empty on the last page.
+type Cursor struct {
+	NextCursor string `json:"next_cursor,omitempty" example:"MTI"`
+}
+
+// Params are the limit and cursor query parameters of a list request.
+type Params struct {
+	Limit int32
+	After sql.NullInt32
+}
+
+// QueryLimit asks for one extra entry so Cut can tell whether a next page exists.
+func (p Params) QueryLimit() int32 {
+	return p.Limit + 1
+}
+
+// FromRequest reads ?limit= and ?cursor=, limit defaults to DefaultLimit and is capped by MaxLimit.
+func FromRequest(r *http.Request) (Params, error) {
+	params := Params{Limit: DefaultLimit}
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		limit, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return Params{}, fmt.Errorf("%w: expected 1..%d", ErrInvalidLimit, MaxLimit)
+		}
+		params.Limit = int32(limit)
+	}
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		id, err := decodeCursor(raw)
+		if err != nil {
+			return Params{}, err
+		}
+		params.After = sql.NullInt32{Int32: id, Valid: true}
+	}
+	return params, nil
+}
+
+// Cut takes the keys of the fetched rows in query order, rows of one entry sharing a key,
+// and returns how many leading rows belong to the page and the cursor of the next page.
+func Cut(keys []int32, limit int32) (int, Cursor) {
+	var entries int32
+	for i, key := range keys {
+		if i > 0 && key == keys[i-1] {
+			continue
+		}
+		entries++
+		if entries > limit {
+			return i, Cursor{NextCursor: encodeCursor(keys[i-1])}
+		}
+	}
+	return len(keys), Cursor{}
+}
+
+// TimeParam reads an optional RFC 3339 query parameter.
+func TimeParam(r *http.Request, name string) (sql.NullTime, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return sql.NullTime{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return sql.NullTime{}, fmt.Errorf("%w: %s must be RFC 3339", ErrInvalidTime, name)
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}, nil
+}
+
+// StringParam reads an optional query parameter, empty means not set.
+func StringParam(r *http.Request, name string) sql.NullString {
+	raw := r.URL.Query().Get(name)
+	return sql.NullString{String: raw, Valid: raw != ""}
+}
+
+// PointParam reads optional lat and lng query parameters, nil means neither is set.
+func PointParam(r *http.Request) (*geo.Point, error) {
+	rawLat, rawLng := r.URL.Query().Get("lat"), r.URL.Query().Get("lng")
+	if rawLat == "" && rawLng == "" {
+		return nil, nil
+	}
+	lat, err := strconv.ParseFloat(rawLat, 64)
+	if err != nil {
+		return nil, ErrInvalidPoint
+	}
+	lng, err := strconv.ParseFloat(rawLng, 64)
+	if err != nil {
+		return nil, ErrInvalidPoint
+	}
+	point := geo.Point{Lat: lat, Lng: lng}
+	if err := point.Validate(); err != nil {
+		return nil, err
+	}
+	return &point, nil
+}
+
+func encodeCursor(id int32) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(int64(id), 10)))
+}
+
+func decodeCursor(cursor string) (int32, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(string(raw), 10, 32)
+	if err != nil || id < 1 {
+		return 0, ErrInvalidCursor
+	}
+	return int32(id), nil
+}
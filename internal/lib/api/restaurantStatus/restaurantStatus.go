@@ -0,0 +1,76 @@
+package restaurantStatus
+
+import (
+	"fmt"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/openingHours"
+	"time"
+)
+
+type Status struct {
+	IsOpen        bool   `json:"is_open" example:"false"`
+	Paused        bool   `json:"paused" example:"false"`
+	NextOpeningAt string `json:"next_opening_at,omitempty" example:"2025-06-17T09:00:00+03:00"`
+}
+
+// Make tells whether the restaurant takes orders at now. hours and holidays must belong to the restaurant.
+func Make(restaurant database.Restaurant, hours []database.RestaurantHour, holidays []database.RestaurantHoliday, now time.Time) (Status, error) {
+	schedule, err := makeSchedule(restaurant.Timezone, hours, holidays)
+	if err != nil {
+		return Status{}, err
+	}
+
+	if restaurant.Paused {
+		// paused until the restaurant resumes by hand, there is no known opening time
+		return Status{Paused: true}, nil
+	}
+
+	if schedule.IsOpen(now) {
+		return Status{IsOpen: true}, nil
+	}
+
+	status := Status{}
+	if next, ok := schedule.NextOpening(now); ok {
+		status.NextOpeningAt = next.Format(time.RFC3339)
+	}
+	return status, nil
+}
+
+func makeSchedule(timezone string, hours []database.RestaurantHour, holidays []database.RestaurantHoliday) (openingHours.Schedule, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return openingHours.Schedule{}, fmt.Errorf("load timezone %q: %w", timezone, err)
+	}
+
+	schedule := openingHours.Schedule{
+		Location:   loc,
+		Weekly:     make([]openingHours.Interval, 0, len(hours)),
+		Exceptions: make([]openingHours.Exception, 0, len(holidays)),
+	}
+	for _, h := range hours {
+		schedule.Weekly = append(schedule.Weekly, openingHours.Interval{
+			Weekday: time.Weekday(h.Weekday),
+			Opens:   sinceMidnight(h.OpensAt),
+			Closes:  sinceMidnight(h.ClosesAt),
+		})
+	}
+	for _, h := range holidays {
+		y, m, d := h.Day.Date()
+		exception := openingHours.Exception{
+			Year:   y,
+			Month:  m,
+			Day:    d,
+			Closed: !h.OpensAt.Valid,
+		}
+		if h.OpensAt.Valid {
+			exception.Opens = sinceMidnight(h.OpensAt.Time)
+			exception.Closes = sinceMidnight(h.ClosesAt.Time)
+		}
+		schedule.Exceptions = append(schedule.Exceptions, exception)
+	}
+	return schedule, nil
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
@@ -0,0 +1,88 @@
+package restaurantStatus
+
+import (
+	"database/sql"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"testing"
+	"time"
+)
+
+// clock is how a TIME column comes back from the database.
+func clock(hour, minute int) time.Time {
+	return time.Date(0, time.January, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestMake(t *testing.T) {
+	// 2025-06-16 is a Monday, 10:00 in Moscow
+	now := time.Date(2025, time.June, 16, 7, 0, 0, 0, time.UTC)
+	mondays := []database.RestaurantHour{{Weekday: int32(time.Monday), OpensAt: clock(9, 0), ClosesAt: clock(18, 0)}}
+	lateMondays := []database.RestaurantHour{{Weekday: int32(time.Monday), OpensAt: clock(12, 0), ClosesAt: clock(18, 0)}}
+
+	testcases := []struct {
+		name       string
+		restaurant database.Restaurant
+		hours      []database.RestaurantHour
+		holidays   []database.RestaurantHoliday
+		want       Status
+		wantErr    bool
+	}{
+		{
+			name:       "no hours",
+			restaurant: database.Restaurant{Timezone: "Europe/Moscow"},
+			want:       Status{IsOpen: true},
+		},
+		{
+			name:       "open",
+			restaurant: database.Restaurant{Timezone: "Europe/Moscow"},
+			hours:      mondays,
+			want:       Status{IsOpen: true},
+		},
+		{
+			name:       "closed until later today in the restaurant time zone",
+			restaurant: database.Restaurant{Timezone: "Europe/Moscow"},
+			hours:      lateMondays,
+			want:       Status{NextOpeningAt: "2025-06-16T12:00:00+03:00"},
+		},
+		{
+			name:       "paused has no opening time",
+			restaurant: database.Restaurant{Timezone: "Europe/Moscow", Paused: true},
+			hours:      mondays,
+			want:       Status{Paused: true},
+		},
+		{
+			name:       "closed holiday",
+			restaurant: database.Restaurant{Timezone: "Europe/Moscow"},
+			hours:      mondays,
+			holidays:   []database.RestaurantHoliday{{Day: time.Date(2025, time.June, 16, 0, 0, 0, 0, time.UTC)}},
+			want:       Status{NextOpeningAt: "2025-06-23T09:00:00+03:00"},
+		},
+		{
+			name:       "holiday with short hours",
+			restaurant: database.Restaurant{Timezone: "Europe/Moscow"},
+			hours:      mondays,
+			holidays: []database.RestaurantHoliday{{
+				Day:      time.Date(2025, time.June, 16, 0, 0, 0, 0, time.UTC),
+				OpensAt:  sql.NullTime{Time: clock(11, 0), Valid: true},
+				ClosesAt: sql.NullTime{Time: clock(15, 0), Valid: true},
+			}},
+			want: Status{NextOpeningAt: "2025-06-16T11:00:00+03:00"},
+		},
+		{
+			name:       "unknown time zone",
+			restaurant: database.Restaurant{Timezone: "Mars/Olympus"},
+			wantErr:    true,
+		},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			got, err := Make(testcase.restaurant, testcase.hours, testcase.holidays, now)
+			if (err != nil) != testcase.wantErr {
+				t.Fatalf("got error %v, want error %v", err, testcase.wantErr)
+			}
+			if got != testcase.want {
+				t.Fatalf("got %+v, want %+v", got, testcase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/yourgfslove/GodFoodApi/internal/events"
+	"github.com/yourgfslove/GodFoodApi/internal/lib/logger/sl"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeartbeatInterval = 15 * time.Second
+	// replayPage is how many stored events are read at once when a client resumes.
+	replayPage = 200
+)
+
+var ErrInvalidLastEventID = errors.New("invalid Last-Event-ID")
+
+// Replayer reads stored events with id greater than afterID in id order.
+type Replayer func(ctx context.Context, afterID int64, limit int32) ([]events.Event, error)
+
+// LastEventID reads the id a reconnecting client resumes after. ok is false on the first connect.
+func LastEventID(r *http.Request) (id int64, ok bool, err error) {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		return 0, false, nil
+	}
+	id, err = strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 0 {
+		return 0, false, ErrInvalidLastEventID
+	}
+	return id, true, nil
+}
+
+// Serve streams events of sub until the client goes away. Stored events after afterID are sent
+// first when replay is set. The subscription must be taken before calling Serve, so events
+// published during the replay are not lost, duplicates are skipped by id.
+func Serve(w http.ResponseWriter, r *http.Request, log *slog.Logger, sub *events.Subscription, afterID int64, replay Replayer) error {
+	var backlog []events.Event
+	if replay != nil {
+		var err error
+		backlog, err = replay(r.Context(), afterID, replayPage)
+		if err != nil {
+			return err
+		}
+	}
+
+	rc := http.NewResponseController(w)
+	// the stream outlives the server write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.Warn("failed to clear write deadline", sl.Err(err))
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	last := afterID
+	for len(backlog) > 0 {
+		for _, e := range backlog {
+			if err := write(w, e); err != nil {
+				return nil
+			}
+			last = e.ID
+		}
+		if len(backlog) < replayPage {
+			break
+		}
+		var err error
+		backlog, err = replay(r.Context(), last, replayPage)
+		if err != nil {
+			log.Error("failed to replay events", sl.Err(err))
+			return nil
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return nil
+	}
+
+	heartbeat := time.NewTicker(HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+		case e, ok := <-sub.C:
+			// closed when the client fell behind, it reconnects with Last-Event-ID
+			if !ok {
+				return nil
+			}
+			if e.ID <= last {
+				continue
+			}
+			if err := write(w, e); err != nil {
+				return nil
+			}
+			last = e.ID
+		}
+		if err := rc.Flush(); err != nil {
+			return nil
+		}
+	}
+}
+
+func write(w http.ResponseWriter, e events.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
@@ -0,0 +1,27 @@
+package staffStruct
+
+import (
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"time"
+)
+
+// Member is a login that works for the restaurant.
+type Member struct {
+	UserID  int32  `json:"user_id" example:"21"`
+	Email   string `json:"email" example:"cook@example.com"`
+	Name    string `json:"name,omitempty" example:"Bill"`
+	Phone   string `json:"phone" example:"89035433434"`
+	Role    string `json:"staff_role" example:"staff"`
+	AddedAt string `json:"added_at" example:"2025-06-17T00:25:16Z"`
+}
+
+func FromRow(row database.GetRestaurantStaffRow) Member {
+	return Member{
+		UserID:  row.UserID,
+		Email:   row.Email,
+		Name:    row.UserName.String,
+		Phone:   row.Phone,
+		Role:    row.StaffRole,
+		AddedAt: row.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,39 @@
+package zoneStruct
+
+import (
+	"encoding/json"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/deliveryZone"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+)
+
+type Zone struct {
+	ID          int32       `json:"id" example:"2"`
+	Name        string      `json:"name" example:"Center"`
+	Kind        string      `json:"kind" example:"radius"`
+	Center      *geo.Point  `json:"center,omitempty"`
+	RadiusM     float64     `json:"radius_m,omitempty" example:"3000"`
+	Polygon     []geo.Point `json:"polygon,omitempty"`
+	MinOrder    money.Money `json:"min_order"`
+	DeliveryFee money.Money `json:"delivery_fee"`
+}
+
+func FromRow(row database.DeliveryZone) (Zone, error) {
+	zone := Zone{
+		ID:          row.ID,
+		Name:        row.Name,
+		Kind:        row.Kind,
+		MinOrder:    money.New(row.MinOrder, row.Currency),
+		DeliveryFee: money.New(row.DeliveryFee, row.Currency),
+	}
+	if deliveryZone.Kind(row.Kind) == deliveryZone.Radius {
+		zone.Center = &geo.Point{Lat: row.CenterLat.Float64, Lng: row.CenterLng.Float64}
+		zone.RadiusM = row.RadiusM.Float64
+		return zone, nil
+	}
+	if err := json.Unmarshal(row.Polygon, &zone.Polygon); err != nil {
+		return Zone{}, err
+	}
+	return zone, nil
+}
@@ -6,12 +6,20 @@ import (
 	"time"
 )
 
-func MakeJWT(userID int32, tokenSecret string, expiresIN time.Duration) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIN)),
-		Issuer:    "GodFood",
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-		Subject:   strconv.Itoa(int(userID)),
+type Claims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+func MakeJWT(userID int32, role string, tokenSecret string, expiresIN time.Duration) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIN)),
+			Issuer:    "GodFood",
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Subject:   strconv.Itoa(int(userID)),
+		},
 	})
 	tokenString, err := token.SignedString([]byte(tokenSecret))
 	if err != nil {
@@ -0,0 +1,36 @@
+package principal
+
+import "context"
+
+const (
+	RoleCustomer   = "customer"
+	RoleCourier    = "courier"
+	RoleRestaurant = "restaurant"
+)
+
+// Staff roles inside a restaurant: the owner manages the staff logins, staff members run the restaurant.
+const (
+	StaffRoleOwner = "owner"
+	StaffRoleStaff = "staff"
+)
+
+// Principal is the authenticated user taken from a validated JWT.
+type Principal struct {
+	ID   int32
+	Role string
+	// RestaurantID is the restaurant the user works for, set only on routes behind the restaurantStaff middleware.
+	RestaurantID int32
+	// StaffRole is the user's role in that restaurant, set together with RestaurantID.
+	StaffRole string
+}
+
+type ctxKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, ctxKey{}, p)
+}
+
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(ctxKey{}).(Principal)
+	return p, ok
+}
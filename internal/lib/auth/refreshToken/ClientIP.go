@@ -0,0 +1,16 @@
+package refreshToken
+
+import (
+	"database/sql"
+	"net"
+	"net/http"
+)
+
+// ClientIP returns the host part of the request's remote address, the ephemeral port is not worth storing.
+func ClientIP(r *http.Request) sql.NullString {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return sql.NullString{String: host, Valid: host != ""}
+}
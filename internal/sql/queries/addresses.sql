@@ -0,0 +1,78 @@
+-- name: CreateAddress :one
+INSERT INTO addresses (user_id, street, building, apartment, entrance, floor, intercom, comment, lat, lng,
+                       located_exactly, is_default, created_at, updated_at)
+VALUES (
+        sqlc.arg(user_id),
+        sqlc.arg(street),
+        sqlc.arg(building),
+        sqlc.arg(apartment),
+        sqlc.arg(entrance),
+        sqlc.arg(floor),
+        sqlc.arg(intercom),
+        sqlc.arg(comment),
+        sqlc.arg(lat),
+        sqlc.arg(lng),
+        sqlc.arg(located_exactly),
+        sqlc.arg(is_default)::boolean OR NOT EXISTS (
+            SELECT 1 FROM addresses WHERE addresses.user_id = sqlc.arg(user_id)
+        ),
+        NOW(),
+        NOW()
+)
+RETURNING *;
+
+-- name: GetAddressesByUserID :many
+SELECT * FROM addresses
+WHERE user_id = $1
+ORDER BY is_default DESC, id;
+
+-- name: GetAddressByID :one
+SELECT * FROM addresses
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetDefaultAddress :one
+SELECT * FROM addresses
+WHERE user_id = $1 AND is_default;
+
+-- name: UpdateAddress :one
+UPDATE addresses
+SET street = sqlc.arg(street),
+    building = sqlc.arg(building),
+    apartment = sqlc.arg(apartment),
+    entrance = sqlc.arg(entrance),
+    floor = sqlc.arg(floor),
+    intercom = sqlc.arg(intercom),
+    comment = sqlc.arg(comment),
+    lat = sqlc.arg(lat),
+    lng = sqlc.arg(lng),
+    located_exactly = sqlc.arg(located_exactly),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: DeleteAddress :one
+DELETE FROM addresses
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: ClearDefaultAddress :exec
+UPDATE addresses
+SET is_default = false
+WHERE user_id = $1 AND is_default;
+
+-- name: SetDefaultAddress :one
+UPDATE addresses
+SET is_default = true,
+    updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: PromoteDefaultAddress :exec
+UPDATE addresses
+SET is_default = true
+WHERE id = (
+    SELECT latest.id FROM addresses AS latest
+    WHERE latest.user_id = $1
+    ORDER BY latest.created_at DESC, latest.id DESC
+    LIMIT 1
+);
@@ -0,0 +1,48 @@
+-- name: UpsertCourierLocation :one
+INSERT INTO courier_locations (courier_id, lat, lng, accuracy, updated_at)
+VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (courier_id) DO UPDATE
+SET lat = EXCLUDED.lat,
+    lng = EXCLUDED.lng,
+    accuracy = EXCLUDED.accuracy,
+    updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: GetCourierLocation :one
+SELECT * FROM courier_locations
+WHERE courier_id = $1;
+
+-- name: GetCourierDistances :many
+SELECT
+    courier_locations.courier_id,
+    (2 * 6371000 * asin(LEAST(1, sqrt(
+        power(sin(radians(courier_locations.lat - restaurants.lat) / 2), 2) +
+        cos(radians(restaurants.lat)) * cos(radians(courier_locations.lat)) *
+        power(sin(radians(courier_locations.lng - restaurants.lng) / 2), 2)
+    ))))::float8 AS distance_m
+FROM courier_locations
+         JOIN restaurants ON restaurants.id = sqlc.arg(restaurant_id)
+WHERE courier_locations.courier_id = ANY(sqlc.arg(courier_ids)::int[])
+  AND restaurants.lat IS NOT NULL
+  AND restaurants.lng IS NOT NULL;
+
+-- name: AddTrackingPoint :exec
+INSERT INTO order_tracking_points (order_id, courier_id, lat, lng, recorded_at)
+VALUES ($1, $2, $3, $4, NOW());
+
+-- name: TrimTrackingPoints :exec
+DELETE FROM order_tracking_points
+WHERE order_tracking_points.order_id = sqlc.arg(order_id)
+  AND order_tracking_points.id < (
+    SELECT MIN(latest.id) FROM (
+        SELECT id FROM order_tracking_points
+        WHERE order_tracking_points.order_id = sqlc.arg(order_id)
+        ORDER BY id DESC
+        LIMIT sqlc.arg(keep)
+    ) AS latest
+);
+
+-- name: GetTrackingPoints :many
+SELECT * FROM order_tracking_points
+WHERE order_id = $1
+ORDER BY id;
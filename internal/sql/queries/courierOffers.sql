@@ -0,0 +1,64 @@
+-- name: GetDispatchCandidates :many
+SELECT
+    couriersstats.id,
+    COALESCE(couriersstats.ordercount, 0)::int AS order_count
+FROM couriersstats
+WHERE couriersstats.status = 'online'
+  AND NOT EXISTS (
+    SELECT 1 FROM orders
+    WHERE orders.courierid = couriersstats.id
+      AND orders.status IN ('accepted', 'preparing', 'ready_for_pickup', 'delivering')
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM courier_offers
+    WHERE courier_offers.courier_id = couriersstats.id
+      AND (courier_offers.order_id = sqlc.arg(order_id)
+        OR (courier_offers.status = 'offered' AND courier_offers.expires_at > NOW()))
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM order_cancellations
+    WHERE order_cancellations.order_id = sqlc.arg(order_id)
+      AND order_cancellations.user_id = couriersstats.id
+  )
+ORDER BY couriersstats.id;
+
+-- name: CreateCourierOffer :one
+INSERT INTO courier_offers (order_id, courier_id, created_at, expires_at)
+VALUES (sqlc.arg(order_id), sqlc.arg(courier_id), NOW(), NOW() + sqlc.arg(timeout_seconds)::int * INTERVAL '1 second')
+RETURNING *;
+
+-- name: GetCourierOfferByID :one
+SELECT * FROM courier_offers
+WHERE id = $1;
+
+-- name: GetOpenOfferForCourier :one
+SELECT * FROM courier_offers
+WHERE courier_id = $1
+  AND status = 'offered'
+  AND expires_at > NOW()
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: RespondCourierOffer :one
+UPDATE courier_offers
+SET status = sqlc.arg(status),
+    responded_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND courier_id = sqlc.arg(courier_id)
+  AND status = 'offered'
+  AND expires_at > NOW()
+RETURNING *;
+
+-- name: ExpireCourierOffer :execrows
+UPDATE courier_offers
+SET status = 'expired'
+WHERE id = $1
+  AND status = 'offered';
+
+-- name: AssignCourier :one
+UPDATE orders
+SET courierid = $1
+WHERE orders.id = $2
+  AND orders.courierid IS NULL
+  AND orders.status IN ('accepted', 'preparing', 'ready_for_pickup')
+RETURNING orders.status;
@@ -0,0 +1,39 @@
+-- name: SetCourierStatus :one
+INSERT INTO couriersstats (id, status, ordercount, status_changed_at)
+VALUES ($1, $2, 0, NOW())
+ON CONFLICT (id) DO UPDATE
+SET status = EXCLUDED.status,
+    status_changed_at = CASE
+        WHEN couriersstats.status = EXCLUDED.status THEN couriersstats.status_changed_at
+        ELSE EXCLUDED.status_changed_at
+    END
+RETURNING *;
+
+-- name: GetCourierStatus :one
+SELECT status FROM couriersstats
+WHERE id = $1;
+
+-- name: IncrementCourierOrderCount :exec
+INSERT INTO couriersstats (id, status, ordercount, status_changed_at)
+VALUES ($1, 'online', 1, NOW())
+ON CONFLICT (id) DO UPDATE
+SET ordercount = couriersstats.ordercount + 1;
+
+-- name: GetCourierProfile :one
+SELECT
+    users.id,
+    users.user_name,
+    users.phone,
+    users.created_at,
+    COALESCE(couriersstats.status, 'offline')::text AS status,
+    couriersstats.status_changed_at,
+    COALESCE(couriersstats.ordercount, 0)::int AS orders_delivered,
+    (SELECT COUNT(*) FROM courier_offers
+     WHERE courier_offers.courier_id = users.id AND courier_offers.status = 'accepted')::int AS offers_accepted,
+    (SELECT COUNT(*) FROM courier_offers
+     WHERE courier_offers.courier_id = users.id AND courier_offers.status = 'declined')::int AS offers_declined,
+    (SELECT COUNT(*) FROM courier_offers
+     WHERE courier_offers.courier_id = users.id AND courier_offers.status = 'expired')::int AS offers_expired
+FROM users
+         LEFT JOIN couriersstats ON couriersstats.id = users.id
+WHERE users.id = $1;
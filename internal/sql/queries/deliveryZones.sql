@@ -0,0 +1,24 @@
+-- name: CreateDeliveryZone :one
+INSERT INTO delivery_zones (restaurant_id, name, kind, center_lat, center_lng, radius_m, polygon, min_order,
+                            delivery_fee, currency, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+RETURNING *;
+
+-- name: GetDeliveryZonesByRestaurantID :many
+SELECT * FROM delivery_zones
+WHERE restaurant_id = $1
+ORDER BY delivery_fee, id;
+
+-- name: DeleteDeliveryZone :execrows
+DELETE FROM delivery_zones
+WHERE id = $1 AND restaurant_id = $2;
+
+-- name: CountDeliveryZones :one
+SELECT COUNT(*) FROM delivery_zones
+WHERE restaurant_id = $1;
+
+-- name: GetDeliveryZonesForPoint :many
+SELECT * FROM delivery_zones
+WHERE restaurant_id = sqlc.arg(restaurant_id)
+  AND delivery_zone_contains(delivery_zones, sqlc.arg(lat)::float8, sqlc.arg(lng)::float8)
+ORDER BY delivery_fee, min_order, id;
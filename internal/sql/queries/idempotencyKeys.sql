@@ -0,0 +1,30 @@
+-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (key, user_id, request_hash, created_at)
+VALUES (
+        $1,
+        $2,
+        $3,
+        NOW()
+)
+ON CONFLICT (user_id, key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    status_code = NULL,
+    response_body = NULL,
+    created_at = EXCLUDED.created_at
+WHERE idempotency_keys.created_at < NOW() - INTERVAL '24 hours'
+   OR (idempotency_keys.status_code IS NULL AND idempotency_keys.created_at < NOW() - INTERVAL '1 minute')
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE key = $1 AND user_id = $2;
+
+-- name: SaveIdempotencyResponse :exec
+UPDATE idempotency_keys
+SET status_code = $3,
+    response_body = $4
+WHERE key = $1 AND user_id = $2;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE key = $1 AND user_id = $2;
\ No newline at end of file
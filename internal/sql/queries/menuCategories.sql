@@ -0,0 +1,29 @@
+-- name: CreateMenuCategory :one
+INSERT INTO menu_categories (restaurant_id, name, position, created_at)
+VALUES (
+        $1,
+        $2,
+        $3,
+        NOW()
+)
+RETURNING *;
+
+-- name: GetMenuCategories :many
+SELECT * FROM menu_categories
+WHERE restaurant_id=$1
+ORDER BY position, id;
+
+-- name: GetMenuCategoryByID :one
+SELECT * FROM menu_categories
+WHERE id=$1;
+
+-- name: UpdateMenuCategory :one
+UPDATE menu_categories
+SET name = COALESCE(sqlc.narg(name), name),
+    position = COALESCE(sqlc.narg(position), position)
+WHERE id = sqlc.arg(id) AND restaurant_id = sqlc.arg(restaurant_id)
+RETURNING *;
+
+-- name: DeleteMenuCategory :execrows
+DELETE FROM menu_categories
+WHERE id=$1 AND restaurant_id=$2;
\ No newline at end of file
@@ -1,19 +1,57 @@
 -- name: GetMenu :many
 SELECT * FROM menuitem
-WHERE restaurant_id=$1;
+WHERE restaurant_id=$1 AND deleted_at IS NULL
+ORDER BY position, id;
 
 -- name: CreateMenuItem :one
-INSERT INTO menuitem (restaurant_id, name, price, description, available)
+INSERT INTO menuitem (restaurant_id, name, price, description, available, currency, category_id, position)
 VALUES (
         $1,
         $2,
         $3,
         $4,
-        $5
+        $5,
+        $6,
+        $7,
+        $8
 )
 RETURNING *;
 
 
 -- name: GetAvailableIDByRestaurantID :many
 SELECT id FROM menuitem
-WHERE restaurant_id=$1 AND available=true;
\ No newline at end of file
+WHERE restaurant_id=$1 AND available=true AND deleted_at IS NULL;
+
+-- name: GetMenuCurrency :one
+SELECT currency FROM menuitem
+WHERE restaurant_id = sqlc.arg(restaurant_id) AND id <> sqlc.arg(except_id) AND deleted_at IS NULL
+ORDER BY id
+LIMIT 1;
+
+-- name: GetMenuItemByID :one
+SELECT * FROM menuitem
+WHERE id=$1 AND deleted_at IS NULL;
+
+-- name: UpdateMenuItem :one
+UPDATE menuitem
+SET name = COALESCE(sqlc.narg(name), name),
+    price = COALESCE(sqlc.narg(price), price),
+    currency = COALESCE(sqlc.narg(currency), currency),
+    description = COALESCE(sqlc.narg(description), description),
+    available = COALESCE(sqlc.narg(available), available),
+    category_id = COALESCE(sqlc.narg(category_id), category_id),
+    position = COALESCE(sqlc.narg(position), position)
+WHERE id = sqlc.arg(id) AND restaurant_id = sqlc.arg(restaurant_id) AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteMenuItem :execrows
+UPDATE menuitem
+SET deleted_at = NOW(),
+    available = false
+WHERE id=$1 AND restaurant_id=$2 AND deleted_at IS NULL;
+
+-- name: SetMenuItemsAvailability :many
+UPDATE menuitem
+SET available = sqlc.arg(available)
+WHERE id = ANY(sqlc.arg(ids)::int[]) AND restaurant_id = sqlc.arg(restaurant_id) AND deleted_at IS NULL
+RETURNING id;
\ No newline at end of file
@@ -0,0 +1,71 @@
+-- name: CreateOptionGroup :one
+INSERT INTO option_groups (menu_item_id, name, kind, min_select, max_select, required, position, created_at)
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        $7,
+        NOW()
+)
+RETURNING *;
+
+-- name: CreateOption :one
+INSERT INTO options (group_id, name, price_delta, available, position)
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5
+)
+RETURNING *;
+
+-- name: GetOptionGroupsByMenuItemIDs :many
+SELECT * FROM option_groups
+WHERE menu_item_id = ANY($1::int[])
+ORDER BY position, id;
+
+-- name: GetOptionsByMenuItemIDs :many
+SELECT options.id, options.group_id, options.name, options.price_delta, options.available, options.position
+FROM options
+         JOIN option_groups ON options.group_id = option_groups.id
+WHERE option_groups.menu_item_id = ANY($1::int[])
+ORDER BY options.position, options.id;
+
+-- name: GetOptionGroupsByRestaurantID :many
+SELECT option_groups.id, option_groups.menu_item_id, option_groups.name, option_groups.kind, option_groups.min_select,
+       option_groups.max_select, option_groups.required, option_groups.position, option_groups.created_at
+FROM option_groups
+         JOIN menuitem ON option_groups.menu_item_id = menuitem.id
+WHERE menuitem.restaurant_id = $1 AND menuitem.deleted_at IS NULL
+ORDER BY option_groups.position, option_groups.id;
+
+-- name: GetOptionsByRestaurantID :many
+SELECT options.id, options.group_id, options.name, options.price_delta, options.available, options.position
+FROM options
+         JOIN option_groups ON options.group_id = option_groups.id
+         JOIN menuitem ON option_groups.menu_item_id = menuitem.id
+WHERE menuitem.restaurant_id = $1 AND menuitem.deleted_at IS NULL
+ORDER BY options.position, options.id;
+
+-- name: UpdateOption :one
+UPDATE options
+SET name = COALESCE(sqlc.narg(name), name),
+    available = COALESCE(sqlc.narg(available), available)
+WHERE options.id = sqlc.arg(id)
+  AND options.group_id IN (
+      SELECT option_groups.id FROM option_groups
+               JOIN menuitem ON option_groups.menu_item_id = menuitem.id
+      WHERE menuitem.restaurant_id = sqlc.arg(restaurant_id)
+  )
+RETURNING *;
+
+-- name: DeleteOptionGroup :execrows
+DELETE FROM option_groups
+USING menuitem
+WHERE option_groups.id = $1
+  AND option_groups.menu_item_id = menuitem.id
+  AND menuitem.restaurant_id = $2;
@@ -0,0 +1,15 @@
+-- name: CreateOrderCancellation :one
+INSERT INTO order_cancellations (order_id, user_id, user_role, reason, created_at)
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        NOW()
+)
+RETURNING *;
+
+-- name: GetCancellationsByOrderID :many
+SELECT * FROM order_cancellations
+WHERE order_id = $1
+ORDER BY created_at;
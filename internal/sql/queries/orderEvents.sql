@@ -0,0 +1,18 @@
+-- name: CreateOrderEvent :one
+INSERT INTO order_events (order_id, restaurant_id, type, status, created_at)
+SELECT orders.id, orders.restaurantid, sqlc.arg(type), sqlc.arg(status), NOW()
+FROM orders
+WHERE orders.id = sqlc.arg(order_id)
+RETURNING *;
+
+-- name: GetOrderEventsAfter :many
+SELECT * FROM order_events
+WHERE order_id = sqlc.arg(order_id) AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
+-- name: GetRestaurantEventsAfter :many
+SELECT * FROM order_events
+WHERE restaurant_id = sqlc.arg(restaurant_id) AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
@@ -1,13 +1,25 @@
--- name: AddItem :exec
-INSERT INTO orderitem(order_id, menu_item_id, quanity)
-VALUES (
-        $1,
-        $2,
-        $3
-       );
+-- name: AddItem :one
+INSERT INTO orderitem(order_id, menu_item_id, quanity, unit_price, item_name, currency, options_price)
+SELECT sqlc.arg(order_id)::int, menuitem.id, sqlc.arg(quanity)::int, menuitem.price, menuitem.name, menuitem.currency,
+       sqlc.arg(options_price)::bigint
+FROM menuitem
+WHERE menuitem.id = sqlc.arg(menu_item_id)
+RETURNING *;
 
+-- name: AddItemOptions :many
+INSERT INTO orderitem_options(orderitem_id, option_id, group_name, option_name, price_delta, currency)
+SELECT sqlc.arg(orderitem_id)::int, options.id, option_groups.name, options.name, options.price_delta,
+       sqlc.arg(currency)::text
+FROM options
+         JOIN option_groups ON options.group_id = option_groups.id
+WHERE options.id = ANY(sqlc.arg(option_ids)::int[])
+ORDER BY option_groups.position, option_groups.id, options.position, options.id
+RETURNING *;
 
--- name: AddItems :many
-INSERT INTO orderitem(order_id, menu_item_id, quanity)
-SELECT unnest($1::int[]), unnest($2::int[]), unnest($3::int[])
-RETURNING *;
\ No newline at end of file
+-- name: GetOrderItemOptions :many
+SELECT orderitem_options.id, orderitem_options.orderitem_id, orderitem_options.option_id, orderitem_options.group_name,
+       orderitem_options.option_name, orderitem_options.price_delta, orderitem_options.currency
+FROM orderitem_options
+         JOIN orderitem ON orderitem_options.orderitem_id = orderitem.id
+WHERE orderitem.order_id = ANY($1::int[])
+ORDER BY orderitem_options.orderitem_id, orderitem_options.id;
@@ -1,9 +1,12 @@
 -- name: CreateOrder :one
-INSERT INTO orders(customerid, restaurantid, address, status, created_at)
+INSERT INTO orders(customerid, restaurantid, address, address_id, delivery_lat, delivery_lng, status, created_at)
 VALUES (
         $1,
         $2,
         $3,
+        $4,
+        $5,
+        $6,
         'pending',
         NOW()
 )
@@ -15,15 +18,18 @@ SELECT
     orders.status,
     orders.created_at,
     orders.address AS delivery_address,
+    orders.total,
+    orders.currency,
 
     orderitem.menu_item_id,
     orderitem.quanity,
-
-    menuitem.name AS menu_item_name,
-    menuitem.price,
+    orderitem.item_name AS menu_item_name,
+    orderitem.unit_price AS price,
+    orderitem.id AS order_item_id,
+    orderitem.options_price,
 
     restaurants.address AS restaurant_address,
-    restaurants.user_name AS restaurant_name,
+    restaurants.name AS restaurant_name,
     restaurants.phone AS restaurant_phone,
 
     customer.user_name AS costomer_name,
@@ -31,10 +37,19 @@ SELECT
 
 FROM orders
          JOIN orderitem ON orders.id = orderitem.order_id
-         JOIN menuitem ON orderitem.menu_item_id = menuitem.id
-         JOIN users AS restaurants ON orders.restaurantid = restaurants.id
+         JOIN restaurants ON orders.restaurantid = restaurants.id
          JOIN users AS customer ON orders.customerid = customer.id
-WHERE orders.customerid = $1;
+WHERE orders.id IN (
+    SELECT page.id FROM orders AS page
+    WHERE page.customerid = sqlc.arg(customerid)
+      AND (sqlc.narg(status)::text IS NULL OR page.status = sqlc.narg(status))
+      AND (sqlc.narg(created_from)::timestamp IS NULL OR page.created_at >= sqlc.narg(created_from))
+      AND (sqlc.narg(created_to)::timestamp IS NULL OR page.created_at < sqlc.narg(created_to))
+      AND (sqlc.narg(after_id)::int IS NULL OR page.id < sqlc.narg(after_id))
+    ORDER BY page.id DESC
+    LIMIT sqlc.arg(page_limit)
+)
+ORDER BY orders.id DESC, orderitem.id;
 
 -- name: GetFullOrderByID :many
 SELECT
@@ -42,15 +57,18 @@ SELECT
     orders.status,
     orders.created_at,
     orders.address AS delivery_address,
+    orders.total,
+    orders.currency,
 
     orderitem.menu_item_id,
     orderitem.quanity,
-
-    menuitem.name AS menu_item_name,
-    menuitem.price,
+    orderitem.item_name AS menu_item_name,
+    orderitem.unit_price AS price,
+    orderitem.id AS order_item_id,
+    orderitem.options_price,
 
     restaurants.address AS restaurant_address,
-    restaurants.user_name AS restaurant_name,
+    restaurants.name AS restaurant_name,
     restaurants.phone AS restaurant_phone,
 
     customer.user_name AS costomer_name,
@@ -61,11 +79,11 @@ SELECT
 
 FROM orders
          JOIN orderitem ON orders.id = orderitem.order_id
-         JOIN menuitem ON orderitem.menu_item_id = menuitem.id
-         JOIN users AS restaurants ON orders.restaurantid = restaurants.id
+         JOIN restaurants ON orders.restaurantid = restaurants.id
          JOIN users AS customer ON orders.customerid = customer.id
         LEFT JOIN users AS courier ON orders.courierid = courier.id
-WHERE orders.id = $1;
+WHERE orders.id = $1
+ORDER BY orderitem.id;
 
 
 -- name: GetFullPendingOrders :many
@@ -74,15 +92,18 @@ SELECT
     orders.status,
     orders.created_at,
     orders.address AS delivery_address,
+    orders.total,
+    orders.currency,
 
     orderitem.menu_item_id,
     orderitem.quanity,
-
-    menuitem.name AS menu_item_name,
-    menuitem.price,
+    orderitem.item_name AS menu_item_name,
+    orderitem.unit_price AS price,
+    orderitem.id AS order_item_id,
+    orderitem.options_price,
 
     restaurants.address AS restaurant_address,
-    restaurants.user_name AS restaurant_name,
+    restaurants.name AS restaurant_name,
     restaurants.phone AS restaurant_phone,
 
     customer.user_name AS costomer_name,
@@ -90,28 +111,59 @@ SELECT
 
 FROM orders
          JOIN orderitem ON orders.id = orderitem.order_id
-         JOIN menuitem ON orderitem.menu_item_id = menuitem.id
-         JOIN users AS restaurants ON orders.restaurantid = restaurants.id
+         JOIN restaurants ON orders.restaurantid = restaurants.id
         JOIN users AS customer ON orders.customerid = customer.id
-WHERE orders.status = 'pending';
+WHERE orders.id IN (
+    SELECT page.id FROM orders AS page
+    WHERE page.status IN ('accepted', 'preparing', 'ready_for_pickup') AND page.courierid IS NULL
+      AND (sqlc.narg(status)::text IS NULL OR page.status = sqlc.narg(status))
+      AND (sqlc.narg(created_from)::timestamp IS NULL OR page.created_at >= sqlc.narg(created_from))
+      AND (sqlc.narg(created_to)::timestamp IS NULL OR page.created_at < sqlc.narg(created_to))
+      AND (sqlc.narg(after_id)::int IS NULL OR page.id < sqlc.narg(after_id))
+    ORDER BY page.id DESC
+    LIMIT sqlc.arg(page_limit)
+)
+ORDER BY orders.id DESC, orderitem.id;
 
 
 
+-- name: GetOrderByID :one
+SELECT * FROM orders
+WHERE orders.id = $1;
+
+-- name: SetOrderStatus :one
+UPDATE orders
+SET status = sqlc.arg(new_status)
+WHERE orders.id = sqlc.arg(id) AND orders.status = sqlc.arg(old_status)
+RETURNING *;
+
+-- name: SetOrderTotals :one
+UPDATE orders
+SET subtotal = $2,
+    delivery_fee = $3,
+    total = $4,
+    currency = $5,
+    delivery_zone_id = $6
+WHERE orders.id = $1
+RETURNING *;
+
 -- name: GetOrderStatusByID :one
 SELECT orders.status FROM orders
 WHERE orders.id = $1;
 
 -- name: GetCurrentIDOrderForCourier :one
 SELECT id FROM orders
-WHERE status = 'delivering' AND courierid=$1;
+WHERE status IN ('accepted', 'preparing', 'ready_for_pickup', 'delivering') AND courierid=$1;
 
 
 -- name: UpdateCourierID :many
 WITH updated_order AS (
+    -- only one of concurrent assigns can match, the others get no rows
     UPDATE orders
-    SET courierid = $1,
-        status = 'delivering'
+    SET courierid = $1
     WHERE orders.id = $2
+      AND orders.courierid IS NULL
+      AND orders.status IN ('accepted', 'preparing', 'ready_for_pickup')
     RETURNING *
 )
 SELECT
@@ -119,15 +171,18 @@ SELECT
     o.status,
     o.created_at,
     o.address AS delivery_address,
+    o.total,
+    o.currency,
 
     orderitem.menu_item_id,
     orderitem.quanity,
-
-    menuitem.name AS menu_item_name,
-    menuitem.price,
+    orderitem.item_name AS menu_item_name,
+    orderitem.unit_price AS price,
+    orderitem.id AS order_item_id,
+    orderitem.options_price,
 
     restaurants.address AS restaurant_address,
-    restaurants.user_name AS restaurant_name,
+    restaurants.name AS restaurant_name,
     restaurants.phone AS restaurant_phone,
 
     customer.user_name AS costomer_name,
@@ -138,10 +193,10 @@ SELECT
 
 FROM updated_order o
          JOIN orderitem ON o.id = orderitem.order_id
-         JOIN menuitem ON orderitem.menu_item_id = menuitem.id
-         JOIN users AS restaurants ON o.restaurantid = restaurants.id
+         JOIN restaurants ON o.restaurantid = restaurants.id
          JOIN users AS customer ON o.customerid = customer.id
-         LEFT JOIN users AS courier ON o.courierid = courier.id;
+         LEFT JOIN users AS courier ON o.courierid = courier.id
+ORDER BY orderitem.id;
 
 
 -- name: GetCurrentOrderForCourier :many
@@ -150,29 +205,52 @@ SELECT
     orders.status,
     orders.created_at,
     orders.address AS delivery_address,
+    orders.total,
+    orders.currency,
 
     orderitem.menu_item_id,
     orderitem.quanity,
-
-    menuitem.name AS menu_item_name,
-    menuitem.price,
+    orderitem.item_name AS menu_item_name,
+    orderitem.unit_price AS price,
+    orderitem.id AS order_item_id,
+    orderitem.options_price,
 
     restaurants.address AS restaurant_address,
-    restaurants.user_name AS restaurant_name,
+    restaurants.name AS restaurant_name,
     restaurants.phone AS restaurant_phone,
 
     customer.phone AS customer_phone
 
 FROM orders
          JOIN orderitem ON orders.id = orderitem.order_id
-         JOIN menuitem ON orderitem.menu_item_id = menuitem.id
-         JOIN users AS restaurants ON orders.restaurantid = restaurants.id
+         JOIN restaurants ON orders.restaurantid = restaurants.id
          JOIN users AS customer ON orders.customerid = customer.id
-WHERE orders.status = 'delivering' AND orders.courierid = $1;
+WHERE orders.status IN ('accepted', 'preparing', 'ready_for_pickup', 'delivering') AND orders.courierid = $1
+ORDER BY orderitem.id;
+
+
+-- name: UpdateOrderStatus :execrows
+UPDATE orders
+SET status = 'delivered'
+WHERE orders.id = $2
+  AND orders.courierid = $1
+  AND orders.status = 'delivering';
 
 
--- name: UpdateOrderStatus :exec
+-- name: DropCourier :execrows
 UPDATE orders
-SET courierid = $1,
-    status = 'delivered'
-WHERE orders.id = $2;
\ No newline at end of file
+SET courierid = NULL
+WHERE orders.id = $1
+  AND orders.courierid = $2
+  AND orders.status IN ('accepted', 'preparing', 'ready_for_pickup');
+
+-- name: UnassignCourier :exec
+UPDATE orders
+SET courierid = NULL
+WHERE orders.id = $1;
+
+
+-- name: GetUndispatchedReadyOrderIDs :many
+SELECT id FROM orders
+WHERE status = 'ready_for_pickup' AND courierid IS NULL
+ORDER BY id;
@@ -1,11 +1,14 @@
 -- name: CreateToken :one
-INSERT INTO refreshTokens (token, created_at, updated_at, user_id, expires_at)
+INSERT INTO refreshTokens (token, created_at, updated_at, user_id, expires_at, family, user_agent, ip)
 VALUES (
     $1,
         NOW(),
         NOW(),
         $2,
-        NOW() + INTERVAL '10 days'
+        NOW() + INTERVAL '10 days',
+        $1,
+        $3,
+        $4
 )
 RETURNING token, expires_at;
 
@@ -18,3 +21,66 @@ WHERE user_id = $1;
 -- name: GetUserByToken :one
 SELECT user_id FROM refreshtokens
 WHERE token = $1;
+
+
+-- name: GetRefreshToken :one
+SELECT * FROM refreshtokens
+WHERE token = $1;
+
+
+-- name: CreateTokenInFamily :one
+INSERT INTO refreshTokens (token, created_at, updated_at, user_id, expires_at, family, user_agent, ip)
+VALUES (
+        $1,
+        NOW(),
+        NOW(),
+        $2,
+        NOW() + INTERVAL '10 days',
+        $3,
+        $4,
+        $5
+)
+RETURNING token, expires_at;
+
+
+-- name: RotateToken :execrows
+UPDATE refreshtokens
+SET revoked_at = NOW(),
+    updated_at = NOW(),
+    replaced_by = $2
+WHERE token = $1 AND revoked_at IS NULL;
+
+
+-- name: RevokeTokenFamily :exec
+UPDATE refreshtokens
+SET revoked_at = NOW(),
+    updated_at = NOW()
+WHERE family = $1 AND revoked_at IS NULL;
+
+
+-- name: GetActiveTokensByUser :many
+SELECT * FROM refreshtokens
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+ORDER BY created_at DESC;
+
+
+-- name: RevokeToken :execrows
+UPDATE refreshtokens
+SET revoked_at = NOW(),
+    updated_at = NOW()
+WHERE token = $1 AND user_id = $2 AND revoked_at IS NULL;
+
+
+-- name: RevokeTokensByUser :execrows
+UPDATE refreshtokens
+SET revoked_at = NOW(),
+    updated_at = NOW()
+WHERE user_id = $1 AND revoked_at IS NULL;
+
+
+-- name: RevokeSession :execrows
+UPDATE refreshtokens
+SET revoked_at = NOW(),
+    updated_at = NOW()
+WHERE user_id = $2 AND revoked_at IS NULL
+  AND family = (SELECT family FROM refreshtokens WHERE id = $1 AND user_id = $2);
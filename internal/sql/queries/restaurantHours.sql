@@ -0,0 +1,45 @@
+-- name: GetRestaurantHours :many
+SELECT * FROM restaurant_hours
+WHERE restaurant_id=$1
+ORDER BY weekday, opens_at;
+
+-- name: GetRestaurantHoursByRestaurantIDs :many
+SELECT * FROM restaurant_hours
+WHERE restaurant_id = ANY($1::int[])
+ORDER BY restaurant_id, weekday, opens_at;
+
+-- name: CreateRestaurantHours :exec
+INSERT INTO restaurant_hours (restaurant_id, weekday, opens_at, closes_at)
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4
+);
+
+-- name: DeleteRestaurantHours :exec
+DELETE FROM restaurant_hours
+WHERE restaurant_id=$1;
+
+-- name: GetRestaurantHolidays :many
+SELECT * FROM restaurant_holidays
+WHERE restaurant_id=$1 AND day >= CURRENT_DATE - 1
+ORDER BY day;
+
+-- name: GetRestaurantHolidaysByRestaurantIDs :many
+SELECT * FROM restaurant_holidays
+WHERE restaurant_id = ANY($1::int[]) AND day >= CURRENT_DATE - 1
+ORDER BY restaurant_id, day;
+
+-- name: CreateRestaurantHoliday :exec
+INSERT INTO restaurant_holidays (restaurant_id, day, opens_at, closes_at)
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4
+);
+
+-- name: DeleteRestaurantHolidays :exec
+DELETE FROM restaurant_holidays
+WHERE restaurant_id=$1;
\ No newline at end of file
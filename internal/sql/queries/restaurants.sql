@@ -0,0 +1,77 @@
+-- name: CreateRestaurant :one
+INSERT INTO restaurants (name, description, cuisine, logo_url, address, phone, created_at)
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6,
+        NOW()
+)
+RETURNING *;
+
+-- name: AddRestaurantStaff :exec
+INSERT INTO restaurant_staff (restaurant_id, user_id, staff_role, created_at)
+VALUES (
+        $1,
+        $2,
+        $3,
+        NOW()
+);
+
+-- name: GetRestaurants :many
+SELECT * FROM restaurants
+WHERE (sqlc.narg(name)::text IS NULL OR name ILIKE '%' || sqlc.narg(name) || '%')
+  AND (sqlc.narg(cuisine)::text IS NULL OR cuisine ILIKE sqlc.narg(cuisine))
+  AND (sqlc.narg(after_id)::int IS NULL OR id > sqlc.narg(after_id))
+  AND (sqlc.narg(lat)::float8 IS NULL OR sqlc.narg(lng)::float8 IS NULL
+    OR NOT EXISTS (SELECT 1 FROM delivery_zones WHERE delivery_zones.restaurant_id = restaurants.id)
+    OR EXISTS (
+        SELECT 1 FROM delivery_zones
+        WHERE delivery_zones.restaurant_id = restaurants.id
+          AND delivery_zone_contains(delivery_zones, sqlc.narg(lat), sqlc.narg(lng))
+    ))
+ORDER BY id
+LIMIT sqlc.arg(page_limit);
+
+-- name: GetRestaurantByID :one
+SELECT * FROM restaurants
+WHERE id=$1;
+
+-- name: GetRestaurantStaffByUserID :one
+SELECT * FROM restaurant_staff
+WHERE user_id=$1;
+
+-- name: GetRestaurantStaff :many
+SELECT restaurant_staff.user_id, restaurant_staff.staff_role, restaurant_staff.created_at,
+       users.email, users.user_name, users.phone
+FROM restaurant_staff
+JOIN users ON users.id = restaurant_staff.user_id
+WHERE restaurant_staff.restaurant_id=$1
+ORDER BY restaurant_staff.created_at, restaurant_staff.user_id;
+
+-- name: DeleteRestaurantStaff :execrows
+DELETE FROM restaurant_staff
+WHERE restaurant_id=$1 AND user_id=$2 AND staff_role='staff';
+
+-- name: UpdateRestaurant :one
+UPDATE restaurants
+SET name = COALESCE(sqlc.narg(name), name),
+    description = COALESCE(sqlc.narg(description), description),
+    cuisine = COALESCE(sqlc.narg(cuisine), cuisine),
+    logo_url = COALESCE(sqlc.narg(logo_url), logo_url),
+    address = COALESCE(sqlc.narg(address), address),
+    phone = COALESCE(sqlc.narg(phone), phone),
+    timezone = COALESCE(sqlc.narg(timezone), timezone),
+    paused = COALESCE(sqlc.narg(paused), paused),
+    lat = CASE WHEN sqlc.narg(address) IS NULL OR sqlc.narg(address) = address THEN lat END,
+    lng = CASE WHEN sqlc.narg(address) IS NULL OR sqlc.narg(address) = address THEN lng END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetRestaurantLocation :exec
+UPDATE restaurants
+SET lat = $2,
+    lng = $3
+WHERE id = $1;
\ No newline at end of file
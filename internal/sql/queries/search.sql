@@ -0,0 +1,36 @@
+-- name: SearchRestaurants :many
+SELECT
+    restaurants.id,
+    restaurants.name,
+    restaurants.description,
+    restaurants.cuisine,
+    restaurants.logo_url,
+    ts_rank(restaurant_search_vector(restaurants.name, restaurants.description, restaurants.cuisine),
+            search_query(sqlc.arg(query)))::real AS rank
+FROM restaurants
+WHERE restaurant_search_vector(restaurants.name, restaurants.description, restaurants.cuisine)
+          @@ search_query(sqlc.arg(query))
+ORDER BY rank DESC, restaurants.id
+LIMIT sqlc.arg(result_limit);
+
+-- name: SearchMenuItems :many
+SELECT
+    menuitem.id,
+    menuitem.restaurant_id,
+    menuitem.name,
+    menuitem.description,
+    menuitem.price,
+    menuitem.currency,
+    restaurants.name AS restaurant_name,
+    restaurants.description AS restaurant_description,
+    restaurants.cuisine AS restaurant_cuisine,
+    restaurants.logo_url AS restaurant_logo_url,
+    ts_rank(menuitem_search_vector(menuitem.name, menuitem.description),
+            search_query(sqlc.arg(query)))::real AS rank
+FROM menuitem
+         JOIN restaurants ON menuitem.restaurant_id = restaurants.id
+WHERE menuitem.deleted_at IS NULL
+  AND menuitem.available IS TRUE
+  AND menuitem_search_vector(menuitem.name, menuitem.description) @@ search_query(sqlc.arg(query))
+ORDER BY rank DESC, menuitem.id
+LIMIT sqlc.arg(result_limit);
@@ -24,25 +24,7 @@ WHERE id=$1;
 SELECT * FROM users
 WHERE user_role=$1;
 
--- name: GetRestaurantAndMenuByID :many
-SELECT
-    users.id AS restaurant_id,
-    users.user_name AS restaurant_name,
-    users.address AS restaurant_address,
-    users.phone AS restaurant_phone,
-
-    menuitem.id AS menu_item_ID,
-    menuitem.name AS menu_item_name,
-    menuitem.price,
-    menuitem.description,
-    menuitem.available
-
-FROM users
-JOIN menuitem ON users.id = menuitem.restaurant_id
-WHERE users.id = $1 AND users.user_role = 'restaurant';
-
 
 -- name: GetNameByID :one
 SELECT user_name FROM users
-WHERE id=$1;
-
+WHERE id=$1;
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- +goose Up
+ALTER TABLE refreshTokens
+ADD COLUMN family TEXT,
+ADD COLUMN replaced_by TEXT;
+
+UPDATE refreshTokens
+SET family = token;
+
+ALTER TABLE refreshTokens
+ALTER COLUMN family SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS refreshTokens_family_idx ON refreshTokens (family);
+
+-- +goose Down
+DROP INDEX IF EXISTS refreshTokens_family_idx;
+
+ALTER TABLE refreshTokens
+DROP COLUMN replaced_by,
+DROP COLUMN family;
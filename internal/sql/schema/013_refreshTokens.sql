@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE refreshTokens
+ADD COLUMN user_agent TEXT,
+ADD COLUMN ip TEXT;
+
+-- +goose Down
+ALTER TABLE refreshTokens
+DROP COLUMN ip,
+DROP COLUMN user_agent;
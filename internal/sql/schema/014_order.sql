@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE orders
+ADD CONSTRAINT orders_status_check CHECK (
+    status IN ('pending', 'accepted', 'preparing', 'ready_for_pickup', 'delivering', 'delivered', 'cancelled', 'rejected')
+);
+
+-- +goose Down
+ALTER TABLE orders
+DROP CONSTRAINT IF EXISTS orders_status_check;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS order_cancellations (
+    id int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    order_id int NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    user_id int NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    user_role TEXT NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS order_cancellations;
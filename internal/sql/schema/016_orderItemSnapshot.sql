@@ -0,0 +1,48 @@
+-- +goose Up
+ALTER TABLE menuitem
+ADD COLUMN currency TEXT NOT NULL DEFAULT 'RUB';
+
+ALTER TABLE orderitem
+ADD COLUMN unit_price FLOAT,
+ADD COLUMN item_name TEXT,
+ADD COLUMN currency TEXT NOT NULL DEFAULT 'RUB';
+
+UPDATE orderitem
+SET unit_price = menuitem.price,
+    item_name = menuitem.name,
+    currency = menuitem.currency
+FROM menuitem
+WHERE orderitem.menu_item_id = menuitem.id;
+
+ALTER TABLE orderitem
+ALTER COLUMN unit_price SET NOT NULL,
+ALTER COLUMN item_name SET NOT NULL;
+
+ALTER TABLE orders
+ADD COLUMN subtotal FLOAT NOT NULL DEFAULT 0,
+ADD COLUMN total FLOAT NOT NULL DEFAULT 0,
+ADD COLUMN currency TEXT NOT NULL DEFAULT 'RUB';
+
+UPDATE orders
+SET subtotal = items.subtotal,
+    total = items.subtotal
+FROM (
+    SELECT order_id, SUM(unit_price * quanity) AS subtotal
+    FROM orderitem
+    GROUP BY order_id
+) AS items
+WHERE orders.id = items.order_id;
+
+-- +goose Down
+ALTER TABLE orders
+DROP COLUMN currency,
+DROP COLUMN total,
+DROP COLUMN subtotal;
+
+ALTER TABLE orderitem
+DROP COLUMN currency,
+DROP COLUMN item_name,
+DROP COLUMN unit_price;
+
+ALTER TABLE menuitem
+DROP COLUMN currency;
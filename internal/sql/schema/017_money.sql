@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE menuitem
+ALTER COLUMN price TYPE BIGINT USING ROUND(price::NUMERIC * 100)::BIGINT;
+
+ALTER TABLE orderitem
+ALTER COLUMN unit_price TYPE BIGINT USING ROUND(unit_price::NUMERIC * 100)::BIGINT;
+
+ALTER TABLE orders
+ALTER COLUMN subtotal TYPE BIGINT USING ROUND(subtotal::NUMERIC * 100)::BIGINT,
+ALTER COLUMN total TYPE BIGINT USING ROUND(total::NUMERIC * 100)::BIGINT;
+
+-- +goose Down
+ALTER TABLE orders
+ALTER COLUMN subtotal TYPE FLOAT USING subtotal / 100.0,
+ALTER COLUMN total TYPE FLOAT USING total / 100.0;
+
+ALTER TABLE orderitem
+ALTER COLUMN unit_price TYPE FLOAT USING unit_price / 100.0;
+
+ALTER TABLE menuitem
+ALTER COLUMN price TYPE FLOAT USING price / 100.0;
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key TEXT NOT NULL,
+    user_id int NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    request_hash TEXT NOT NULL,
+    status_code int,
+    response_body BYTEA,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS idempotency_keys;
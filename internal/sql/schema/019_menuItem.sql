@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE menuitem
+ADD COLUMN deleted_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE menuitem
+DROP COLUMN deleted_at;
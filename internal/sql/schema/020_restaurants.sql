@@ -0,0 +1,53 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS restaurants (
+    id int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    cuisine TEXT,
+    logo_url TEXT,
+    address TEXT NOT NULL,
+    phone TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS restaurant_staff (
+    restaurant_id int NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    user_id int NOT NULL UNIQUE REFERENCES users (id) ON DELETE CASCADE,
+    staff_role TEXT NOT NULL CHECK (staff_role IN ('owner', 'staff')),
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (restaurant_id, user_id)
+);
+
+-- every restaurant account becomes its own restaurant with the same id,
+-- so menuitem.restaurant_id and orders.restaurantid stay valid
+INSERT INTO restaurants (id, name, address, phone, created_at)
+SELECT id, COALESCE(user_name, email), COALESCE(address, ''), phone, created_at
+FROM users
+WHERE user_role = 'restaurant';
+
+SELECT setval(pg_get_serial_sequence('restaurants', 'id'), COALESCE(MAX(id), 0) + 1, false)
+FROM restaurants;
+
+INSERT INTO restaurant_staff (restaurant_id, user_id, staff_role, created_at)
+SELECT id, id, 'owner', NOW()
+FROM restaurants;
+
+ALTER TABLE menuitem
+DROP CONSTRAINT menuitem_restaurant_id_fkey,
+ADD CONSTRAINT menuitem_restaurant_id_fkey FOREIGN KEY (restaurant_id) REFERENCES restaurants (id) ON DELETE CASCADE;
+
+ALTER TABLE orders
+DROP CONSTRAINT orders_restaurantid_fkey,
+ADD CONSTRAINT orders_restaurantid_fkey FOREIGN KEY (restaurantid) REFERENCES restaurants (id) ON DELETE CASCADE;
+
+-- +goose Down
+ALTER TABLE orders
+DROP CONSTRAINT orders_restaurantid_fkey,
+ADD CONSTRAINT orders_restaurantid_fkey FOREIGN KEY (restaurantid) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE menuitem
+DROP CONSTRAINT menuitem_restaurant_id_fkey,
+ADD CONSTRAINT menuitem_restaurant_id_fkey FOREIGN KEY (restaurant_id) REFERENCES users (id) ON DELETE CASCADE;
+
+DROP TABLE IF EXISTS restaurant_staff;
+DROP TABLE IF EXISTS restaurants;
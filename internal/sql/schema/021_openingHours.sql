@@ -0,0 +1,34 @@
+-- +goose Up
+ALTER TABLE restaurants
+ADD COLUMN timezone TEXT NOT NULL DEFAULT 'Europe/Moscow',
+ADD COLUMN paused BOOLEAN NOT NULL DEFAULT false;
+
+-- weekday follows Go's time.Weekday: 0 is Sunday.
+-- closes_at <= opens_at means the restaurant closes after midnight.
+CREATE TABLE IF NOT EXISTS restaurant_hours (
+    id int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    restaurant_id int NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    weekday int NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS restaurant_hours_restaurant_id_idx ON restaurant_hours (restaurant_id);
+
+-- a holiday without opens_at is closed for the whole day
+CREATE TABLE IF NOT EXISTS restaurant_holidays (
+    restaurant_id int NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    opens_at TIME,
+    closes_at TIME,
+    PRIMARY KEY (restaurant_id, day),
+    CHECK ((opens_at IS NULL) = (closes_at IS NULL))
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS restaurant_holidays;
+DROP TABLE IF EXISTS restaurant_hours;
+
+ALTER TABLE restaurants
+DROP COLUMN paused,
+DROP COLUMN timezone;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS menu_categories (
+    id int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    restaurant_id int NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    position int NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS menu_categories_restaurant_id_idx ON menu_categories (restaurant_id);
+
+ALTER TABLE menuitem
+ADD COLUMN category_id int REFERENCES menu_categories (id) ON DELETE SET NULL,
+ADD COLUMN position int NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE menuitem
+DROP COLUMN position,
+DROP COLUMN category_id;
+
+DROP TABLE IF EXISTS menu_categories;
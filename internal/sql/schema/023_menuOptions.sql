@@ -0,0 +1,59 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS option_groups (
+    id int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    menu_item_id int NOT NULL REFERENCES menuitem (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL DEFAULT 'single' CHECK (kind IN ('single', 'multi')),
+    min_select int NOT NULL DEFAULT 0 CHECK (min_select >= 0),
+    max_select int NOT NULL DEFAULT 1 CHECK (max_select >= 1),
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    position int NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    CHECK (min_select <= max_select)
+);
+
+CREATE INDEX IF NOT EXISTS option_groups_menu_item_id_idx ON option_groups (menu_item_id);
+
+CREATE TABLE IF NOT EXISTS options (
+    id int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    group_id int NOT NULL REFERENCES option_groups (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    price_delta BIGINT NOT NULL DEFAULT 0,
+    available BOOLEAN NOT NULL DEFAULT TRUE,
+    position int NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS options_group_id_idx ON options (group_id);
+
+-- the same menu item can now be ordered twice with different options
+ALTER TABLE orderitem
+DROP CONSTRAINT IF EXISTS orderitem_pkey,
+ADD COLUMN id int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+ADD COLUMN options_price BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS orderitem_order_id_idx ON orderitem (order_id);
+
+CREATE TABLE IF NOT EXISTS orderitem_options (
+    id int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    orderitem_id int NOT NULL REFERENCES orderitem (id) ON DELETE CASCADE,
+    option_id int REFERENCES options (id) ON DELETE SET NULL,
+    group_name TEXT NOT NULL,
+    option_name TEXT NOT NULL,
+    price_delta BIGINT NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'RUB'
+);
+
+CREATE INDEX IF NOT EXISTS orderitem_options_orderitem_id_idx ON orderitem_options (orderitem_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS orderitem_options;
+
+DROP INDEX IF EXISTS orderitem_order_id_idx;
+
+ALTER TABLE orderitem
+DROP COLUMN options_price,
+DROP COLUMN id,
+ADD PRIMARY KEY (order_id, menu_item_id);
+
+DROP TABLE IF EXISTS options;
+DROP TABLE IF EXISTS option_groups;
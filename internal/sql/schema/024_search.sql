@@ -0,0 +1,50 @@
+-- +goose Up
+-- search vectors are built by immutable functions so the same expression serves the GIN indexes and the queries
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION restaurant_search_vector(name TEXT, description TEXT, cuisine TEXT)
+RETURNS tsvector
+LANGUAGE sql IMMUTABLE
+AS $$
+    SELECT setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+           setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+           setweight(to_tsvector('russian', coalesce(cuisine, '')), 'B') ||
+           setweight(to_tsvector('english', coalesce(cuisine, '')), 'B') ||
+           setweight(to_tsvector('russian', coalesce(description, '')), 'C') ||
+           setweight(to_tsvector('english', coalesce(description, '')), 'C')
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION menuitem_search_vector(name TEXT, description TEXT)
+RETURNS tsvector
+LANGUAGE sql IMMUTABLE
+AS $$
+    SELECT setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+           setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+           setweight(to_tsvector('russian', coalesce(description, '')), 'B') ||
+           setweight(to_tsvector('english', coalesce(description, '')), 'B')
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION search_query(q TEXT)
+RETURNS tsquery
+LANGUAGE sql IMMUTABLE
+AS $$
+    SELECT websearch_to_tsquery('russian', q) || websearch_to_tsquery('english', q)
+$$;
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS restaurants_search_idx ON restaurants
+USING GIN (restaurant_search_vector(name, description, cuisine));
+
+CREATE INDEX IF NOT EXISTS menuitem_search_idx ON menuitem
+USING GIN (menuitem_search_vector(name, description))
+WHERE deleted_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS menuitem_search_idx;
+DROP INDEX IF EXISTS restaurants_search_idx;
+DROP FUNCTION IF EXISTS search_query(TEXT);
+DROP FUNCTION IF EXISTS menuitem_search_vector(TEXT, TEXT);
+DROP FUNCTION IF EXISTS restaurant_search_vector(TEXT, TEXT, TEXT);
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS courier_offers (
+    id int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    order_id int NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    courier_id int NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'offered' CHECK (status IN ('offered', 'accepted', 'declined', 'expired')),
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    responded_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS courier_offers_order_id_idx ON courier_offers (order_id);
+CREATE INDEX IF NOT EXISTS courier_offers_open_idx ON courier_offers (courier_id) WHERE status = 'offered';
+
+-- +goose Down
+DROP TABLE IF EXISTS courier_offers;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- one stats row per courier, keep the last one of duplicates
+DELETE FROM couriersstats a
+USING couriersstats b
+WHERE a.id = b.id AND a.ctid < b.ctid;
+
+UPDATE couriersstats SET status = 'offline' WHERE status NOT IN ('online', 'offline', 'on_break');
+UPDATE couriersstats SET ordercount = 0 WHERE ordercount IS NULL;
+
+ALTER TABLE couriersstats
+ADD PRIMARY KEY (id),
+ADD CONSTRAINT couriersstats_status_check CHECK (status IN ('online', 'offline', 'on_break')),
+ALTER COLUMN ordercount SET NOT NULL,
+ADD COLUMN status_changed_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- +goose Down
+ALTER TABLE couriersstats
+DROP COLUMN IF EXISTS status_changed_at,
+ALTER COLUMN ordercount DROP NOT NULL,
+DROP CONSTRAINT IF EXISTS couriersstats_status_check,
+DROP CONSTRAINT IF EXISTS couriersstats_pkey;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS courier_locations (
+    courier_id int PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    lat DOUBLE PRECISION NOT NULL CHECK (lat BETWEEN -90 AND 90),
+    lng DOUBLE PRECISION NOT NULL CHECK (lng BETWEEN -180 AND 180),
+    accuracy DOUBLE PRECISION,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- the trail is kept only for orders being delivered and trimmed to the latest points
+CREATE TABLE IF NOT EXISTS order_tracking_points (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    order_id int NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    courier_id int NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    lat DOUBLE PRECISION NOT NULL,
+    lng DOUBLE PRECISION NOT NULL,
+    recorded_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_tracking_points_order_id_idx ON order_tracking_points (order_id, id);
+
+-- +goose Down
+DROP TABLE IF EXISTS order_tracking_points;
+DROP TABLE IF EXISTS courier_locations;
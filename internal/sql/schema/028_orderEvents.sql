@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS order_events (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    order_id int NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    restaurant_id int NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    status TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_events_order_id_idx ON order_events (order_id, id);
+CREATE INDEX IF NOT EXISTS order_events_restaurant_id_idx ON order_events (restaurant_id, id);
+
+-- +goose Down
+DROP TABLE IF EXISTS order_events;
@@ -0,0 +1,36 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS addresses (
+    id int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id int NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    street TEXT NOT NULL,
+    building TEXT NOT NULL,
+    apartment TEXT NOT NULL DEFAULT '',
+    entrance TEXT NOT NULL DEFAULT '',
+    floor TEXT NOT NULL DEFAULT '',
+    intercom TEXT NOT NULL DEFAULT '',
+    comment TEXT NOT NULL DEFAULT '',
+    lat DOUBLE PRECISION NOT NULL CHECK (lat BETWEEN -90 AND 90),
+    lng DOUBLE PRECISION NOT NULL CHECK (lng BETWEEN -180 AND 180),
+    is_default BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS addresses_user_id_idx ON addresses (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS addresses_default_idx ON addresses (user_id) WHERE is_default;
+
+-- orders keep the text and the point they were placed with, the saved address may change later
+ALTER TABLE orders
+ADD COLUMN address_id int REFERENCES addresses (id) ON DELETE SET NULL,
+ADD COLUMN delivery_lat DOUBLE PRECISION,
+ADD COLUMN delivery_lng DOUBLE PRECISION,
+ALTER COLUMN address DROP DEFAULT;
+
+-- +goose Down
+ALTER TABLE orders
+ALTER COLUMN address SET DEFAULT 'unset',
+DROP COLUMN delivery_lng,
+DROP COLUMN delivery_lat,
+DROP COLUMN address_id;
+
+DROP TABLE IF EXISTS addresses;
@@ -0,0 +1,54 @@
+-- +goose Up
+-- a radius zone is a circle around center, a polygon zone lists its vertices as [{"lat": .., "lng": ..}, ...]
+CREATE TABLE IF NOT EXISTS delivery_zones (
+    id int GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    restaurant_id int NOT NULL REFERENCES restaurants (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('radius', 'polygon')),
+    center_lat DOUBLE PRECISION,
+    center_lng DOUBLE PRECISION,
+    radius_m DOUBLE PRECISION,
+    polygon JSONB NOT NULL DEFAULT '[]',
+    min_order BIGINT NOT NULL DEFAULT 0 CHECK (min_order >= 0),
+    delivery_fee BIGINT NOT NULL DEFAULT 0 CHECK (delivery_fee >= 0),
+    currency TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    CHECK (kind <> 'radius' OR (center_lat IS NOT NULL AND center_lng IS NOT NULL AND radius_m > 0)),
+    CHECK (kind <> 'polygon' OR jsonb_array_length(polygon) >= 3)
+);
+
+CREATE INDEX IF NOT EXISTS delivery_zones_restaurant_id_idx ON delivery_zones (restaurant_id);
+
+-- distances are great-circle over a sphere of the mean Earth radius, polygons are treated as flat
+-- in degrees, which is accurate enough at city scale
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION delivery_zone_contains(zone delivery_zones, lat DOUBLE PRECISION, lng DOUBLE PRECISION)
+RETURNS boolean
+LANGUAGE sql IMMUTABLE
+AS $$
+    SELECT CASE zone.kind
+        WHEN 'radius' THEN
+            2 * 6371000 * asin(LEAST(1, sqrt(
+                power(sin(radians(lat - zone.center_lat) / 2), 2) +
+                cos(radians(zone.center_lat)) * cos(radians(lat)) *
+                power(sin(radians(lng - zone.center_lng) / 2), 2)
+            ))) <= zone.radius_m
+        ELSE (
+            SELECT ('(' || string_agg('(' || (p ->> 'lng') || ',' || (p ->> 'lat') || ')', ',' ORDER BY ord) || ')')::polygon
+            FROM jsonb_array_elements(zone.polygon) WITH ORDINALITY AS points (p, ord)
+        ) @> point(lng, lat)
+    END
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE orders
+ADD COLUMN delivery_zone_id int REFERENCES delivery_zones (id) ON DELETE SET NULL,
+ADD COLUMN delivery_fee BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE orders
+DROP COLUMN delivery_fee,
+DROP COLUMN delivery_zone_id;
+
+DROP FUNCTION IF EXISTS delivery_zone_contains(delivery_zones, DOUBLE PRECISION, DOUBLE PRECISION);
+DROP TABLE IF EXISTS delivery_zones;
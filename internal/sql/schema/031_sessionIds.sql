@@ -0,0 +1,8 @@
+-- +goose Up
+-- a session is a token family, it is addressed by the id of any token issued in it
+ALTER TABLE refreshtokens
+ADD COLUMN id bigint GENERATED BY DEFAULT AS IDENTITY UNIQUE;
+
+-- +goose Down
+ALTER TABLE refreshtokens
+DROP COLUMN id;
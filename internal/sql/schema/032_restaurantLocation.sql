@@ -0,0 +1,10 @@
+-- +goose Up
+-- filled by geocoding the address when the restaurant is first needed on the map, cleared when the address changes
+ALTER TABLE restaurants
+ADD COLUMN lat DOUBLE PRECISION,
+ADD COLUMN lng DOUBLE PRECISION;
+
+-- +goose Down
+ALTER TABLE restaurants
+DROP COLUMN lng,
+DROP COLUMN lat;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- a point is exact when the customer pinned it or a real geocoder found it, earlier rows may be made up
+ALTER TABLE addresses
+ADD COLUMN located_exactly BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE addresses
+DROP COLUMN located_exactly;
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/yourgfslove/GodFoodApi/internal/database"
+)
+
+// Storage is the sqlc query set bound to the connection pool, plus the ability
+// to run several queries in one transaction.
+type Storage struct {
+	*database.Queries
+	db *sql.DB
+}
+
+func New(db *sql.DB) *Storage {
+	return &Storage{
+		Queries: database.New(db),
+		db:      db,
+	}
+}
+
+// InTx runs fn with queries bound to a new transaction. The transaction is
+// committed if fn returns nil and rolled back otherwise.
+func (s *Storage) InTx(ctx context.Context, fn func(q *database.Queries) error) error {
+	const op = "storage.InTx"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: begin: %w", op, err)
+	}
+
+	if err := fn(s.Queries.WithTx(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return fmt.Errorf("%s: rollback: %v (original error: %w)", op, rbErr, err)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: commit: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"github.com/gavv/httpexpect/v2"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/addresses/newAddress"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func Test_addressBook(t *testing.T) {
+	u := url.URL{
+		Scheme: "http",
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+	auth := "Bearer " + registerUser(e, "customer")
+
+	home := e.POST("/users/me/addresses").
+		WithHeader("Authorization", auth).
+		WithJSON(newAddress.Request{
+			Street:    "Tverskaya",
+			Building:  "7",
+			Apartment: "12",
+			Intercom:  "12K",
+		}).Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	// the first address becomes the default one and is geocoded
+	home.Value("is_default").Boolean().IsTrue()
+	home.Value("formatted").String().IsEqual("Tverskaya, 7, apt 12, intercom 12K")
+	home.Value("location").Object().Value("lat").Number().NotEqual(0)
+	homeID := int(home.Value("id").Number().Raw())
+
+	work := e.POST("/users/me/addresses").
+		WithHeader("Authorization", auth).
+		WithJSON(newAddress.Request{
+			Street:    "Arbat",
+			Building:  "10",
+			Location:  &geo.Point{Lat: 55.7494, Lng: 37.5912},
+			IsDefault: true,
+		}).Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	work.Value("is_default").Boolean().IsTrue()
+	work.Value("location").Object().Value("lat").Number().IsEqual(55.7494)
+	workID := int(work.Value("id").Number().Raw())
+
+	list := e.GET("/users/me/addresses").
+		WithHeader("Authorization", auth).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("addresses").Array()
+	list.Length().IsEqual(2)
+	list.Value(0).Object().Value("id").Number().IsEqual(workID)
+	list.Value(1).Object().Value("is_default").Boolean().IsFalse()
+
+	e.PATCH("/users/me/addresses/"+strconv.Itoa(homeID)).
+		WithHeader("Authorization", auth).
+		WithJSON(map[string]any{"floor": "5"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("formatted").String().IsEqual("Tverskaya, 7, apt 12, floor 5, intercom 12K")
+
+	e.PATCH("/users/me/addresses/"+strconv.Itoa(homeID)).
+		WithHeader("Authorization", auth).
+		WithJSON(map[string]any{"street": " "}).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	// deleting the default address makes the latest one the default
+	e.DELETE("/users/me/addresses/"+strconv.Itoa(workID)).
+		WithHeader("Authorization", auth).
+		Expect().
+		Status(http.StatusNoContent)
+	e.GET("/users/me/addresses").
+		WithHeader("Authorization", auth).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("addresses").Array().
+		Value(0).Object().Value("is_default").Boolean().IsTrue()
+
+	e.PATCH("/users/me/addresses/"+strconv.Itoa(workID)+"/default").
+		WithHeader("Authorization", auth).
+		Expect().
+		Status(http.StatusNotFound)
+
+	// someone else's address is not found
+	e.DELETE("/users/me/addresses/"+strconv.Itoa(homeID)).
+		WithHeader("Authorization", "Bearer "+registerUser(e, "customer")).
+		Expect().
+		Status(http.StatusNotFound)
+}
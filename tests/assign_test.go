@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/auth/register"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/couriers/setStatus"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/newMenuItem"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+const couriersInRace = 10
+
+func registerUser(e *httpexpect.Expect, role string) string {
+	return e.POST("/register").WithJSON(register.Request{
+		Email:    gofakeit.Email(),
+		Password: "qwerty1234",
+		Role:     role,
+		Phone:    "+79035433434",
+		Address:  gofakeit.Address().Address,
+		Name:     gofakeit.Username(),
+	}).Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("jwt").String().Raw()
+}
+
+func Test_assignRace(t *testing.T) {
+	u := url.URL{
+		Scheme: "http",
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	restaurantJWT := registerUser(e, "restaurant")
+	item := e.POST("/restaurants/menuItems").
+		WithHeader("Authorization", "Bearer "+restaurantJWT).
+		WithJSON(newMenuItem.Request{
+			Price:     money.New(30000, "RUB"),
+			Name:      "Burger",
+			Available: true,
+		}).Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	itemID := int32(item.Value("id").Number().Raw())
+	restaurantID := int32(item.Value("restaurant_id").Number().Raw())
+
+	customerJWT := registerUser(e, "customer")
+	orderID := int(e.POST("/orders").
+		WithHeader("Authorization", "Bearer "+customerJWT).
+		WithJSON(map[string]any{
+			"restaurant_id": restaurantID,
+			"address":       gofakeit.Address().Address,
+			"items":         []map[string]any{{"menuitem_id": itemID, "quantity": 1}},
+		}).Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("order_id").Number().Raw())
+	orderPath := "/orders/" + strconv.Itoa(orderID)
+
+	e.PATCH(orderPath+"/accept").
+		WithHeader("Authorization", "Bearer "+restaurantJWT).
+		Expect().
+		Status(http.StatusOK)
+
+	couriers := make([]string, 0, couriersInRace)
+	for i := 0; i < couriersInRace; i++ {
+		jwt := registerUser(e, "courier")
+		e.PATCH("/couriers/me/status").
+			WithHeader("Authorization", "Bearer "+jwt).
+			WithJSON(setStatus.Request{Status: "online"}).
+			Expect().
+			Status(http.StatusOK)
+		couriers = append(couriers, jwt)
+	}
+
+	// all couriers hit assign at the same moment, exactly one of them must win
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	statuses := make([]int, len(couriers))
+	for i, jwt := range couriers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			resp := e.PATCH(orderPath+"/assign").
+				WithHeader("Authorization", "Bearer "+jwt).
+				Expect().
+				Raw()
+			// a failed request is already reported by httpexpect and leaves no response
+			if resp != nil {
+				statuses[i] = resp.StatusCode
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	won, lost := 0, 0
+	for _, status := range statuses {
+		switch status {
+		case http.StatusOK:
+			won++
+		case http.StatusConflict:
+			lost++
+		default:
+			t.Errorf("unexpected status %d", status)
+		}
+	}
+	if won != 1 || lost != len(couriers)-1 {
+		t.Fatalf("won = %d, lost = %d, want exactly one winner among %d couriers", won, lost, len(couriers))
+	}
+}
@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/auth/refresh"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/auth/register"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func Test_refresh(t *testing.T) {
+	u := url.URL{
+		Scheme: "http",
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	firstToken := e.POST("/register").WithJSON(register.Request{
+		Email:    gofakeit.Email(),
+		Password: "qwerty1234",
+		Role:     "customer",
+		Phone:    "+79035433434",
+		Address:  gofakeit.Address().Address,
+		Name:     gofakeit.Username(),
+	}).Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("refresh_token").String().Raw()
+
+	secondToken := e.POST("/refresh").WithJSON(refresh.Request{
+		RefreshToken: firstToken,
+	}).Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("refresh_token").String().NotEqual(firstToken).Raw()
+
+	// replaying the rotated token revokes the whole family
+	e.POST("/refresh").WithJSON(refresh.Request{
+		RefreshToken: firstToken,
+	}).Expect().
+		Status(http.StatusUnauthorized)
+
+	e.POST("/refresh").WithJSON(refresh.Request{
+		RefreshToken: secondToken,
+	}).Expect().
+		Status(http.StatusUnauthorized)
+
+	e.POST("/refresh").WithJSON(refresh.Request{
+		RefreshToken: "unknown",
+	}).Expect().
+		Status(http.StatusUnauthorized)
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/newCategory"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/staff/newStaff"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func Test_restaurantStaff(t *testing.T) {
+	u := url.URL{
+		Scheme: "http",
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+	ownerAuth := "Bearer " + registerUser(e, "restaurant")
+
+	email := gofakeit.Email()
+	member := e.POST("/restaurants/me/staff").
+		WithHeader("Authorization", ownerAuth).
+		WithJSON(newStaff.Request{
+			Email:    email,
+			Password: "qwerty1234",
+			Phone:    "+79035433434",
+			Name:     "Cook",
+		}).Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	member.Value("staff_role").String().IsEqual("staff")
+	staffPath := "/restaurants/me/staff/" + strconv.Itoa(int(member.Value("user_id").Number().Raw()))
+
+	e.POST("/restaurants/me/staff").
+		WithHeader("Authorization", ownerAuth).
+		WithJSON(newStaff.Request{Email: email, Password: "qwerty1234", Phone: "+79035433434"}).
+		Expect().
+		Status(http.StatusConflict)
+
+	staffAuth := "Bearer " + e.POST("/login").
+		WithJSON(map[string]any{"email": email, "password": "qwerty1234"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("jwt").String().Raw()
+
+	// the staff login works for the owner's restaurant
+	e.POST("/restaurants/categories").
+		WithHeader("Authorization", staffAuth).
+		WithJSON(newCategory.Request{Name: "Drinks"}).
+		Expect().
+		Status(http.StatusCreated)
+	staff := e.GET("/restaurants/me/staff").
+		WithHeader("Authorization", staffAuth).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("staff").Array()
+	staff.Length().IsEqual(2)
+	staff.Value(0).Object().Value("staff_role").String().IsEqual("owner")
+
+	// only the owner manages staff
+	e.POST("/restaurants/me/staff").
+		WithHeader("Authorization", staffAuth).
+		WithJSON(newStaff.Request{Email: gofakeit.Email(), Password: "qwerty1234", Phone: "+79035433434"}).
+		Expect().
+		Status(http.StatusForbidden)
+	e.DELETE(staffPath).
+		WithHeader("Authorization", staffAuth).
+		Expect().
+		Status(http.StatusForbidden)
+
+	e.DELETE(staffPath).
+		WithHeader("Authorization", ownerAuth).
+		Expect().
+		Status(http.StatusNoContent)
+	e.DELETE(staffPath).
+		WithHeader("Authorization", ownerAuth).
+		Expect().
+		Status(http.StatusNotFound)
+
+	// a detached login loses access right away
+	e.POST("/restaurants/categories").
+		WithHeader("Authorization", staffAuth).
+		WithJSON(newCategory.Request{Name: "Desserts"}).
+		Expect().
+		Status(http.StatusForbidden)
+}
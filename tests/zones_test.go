@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"github.com/gavv/httpexpect/v2"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/geo"
+	"github.com/yourgfslove/GodFoodApi/internal/domain/money"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/addresses/newAddress"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/addresses/updateAddress"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/deliveryZones/newDeliveryZone"
+	"github.com/yourgfslove/GodFoodApi/internal/http-server/restaurants/menu/newMenuItem"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func Test_deliveryZones(t *testing.T) {
+	u := url.URL{
+		Scheme: "http",
+		Host:   host,
+	}
+	e := httpexpect.Default(t, u.String())
+
+	restaurantAuth := "Bearer " + registerUser(e, "restaurant")
+	item := e.POST("/restaurants/menuItems").
+		WithHeader("Authorization", restaurantAuth).
+		WithJSON(newMenuItem.Request{
+			Price:     money.New(25000, "RUB"),
+			Name:      "Burger",
+			Available: true,
+		}).Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	itemID := int32(item.Value("id").Number().Raw())
+	restaurantID := int32(item.Value("restaurant_id").Number().Raw())
+
+	e.POST("/restaurants/me/zones").
+		WithHeader("Authorization", restaurantAuth).
+		WithJSON(newDeliveryZone.Request{
+			Name:        "Center",
+			Kind:        "radius",
+			Center:      &geo.Point{Lat: 55.7558, Lng: 37.6173},
+			RadiusM:     3000,
+			MinOrder:    money.New(60000, "RUB"),
+			DeliveryFee: money.New(9900, "RUB"),
+		}).Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Value("delivery_fee").Object().Value("amount").Number().IsEqual(9900)
+
+	e.POST("/restaurants/me/zones").
+		WithHeader("Authorization", restaurantAuth).
+		WithJSON(newDeliveryZone.Request{
+			Name:        "City",
+			Kind:        "radius",
+			Center:      &geo.Point{Lat: 55.7558, Lng: 37.6173},
+			RadiusM:     10000,
+			MinOrder:    money.New(40000, "RUB"),
+			DeliveryFee: money.New(19900, "RUB"),
+		}).Expect().
+		Status(http.StatusCreated)
+
+	e.POST("/restaurants/me/zones").
+		WithHeader("Authorization", restaurantAuth).
+		WithJSON(newDeliveryZone.Request{
+			Name:    "Triangle",
+			Kind:    "polygon",
+			Polygon: []geo.Point{{Lat: 55.7, Lng: 37.6}, {Lat: 55.8, Lng: 37.6}},
+		}).Expect().
+		Status(http.StatusBadRequest)
+
+	e.GET("/restaurants/" + strconv.Itoa(int(restaurantID)) + "/zones").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("zones").Array().Length().IsEqual(2)
+
+	customerAuth := "Bearer " + registerUser(e, "customer")
+	addAddress := func(location geo.Point) int32 {
+		return int32(e.POST("/users/me/addresses").
+			WithHeader("Authorization", customerAuth).
+			WithJSON(newAddress.Request{
+				Street:   "Tverskaya",
+				Building: "7",
+				Location: &location,
+			}).Expect().
+			Status(http.StatusCreated).
+			JSON().Object().Value("id").Number().Raw())
+	}
+	inside := addAddress(geo.Point{Lat: 55.7601, Lng: 37.6187})
+	outside := addAddress(geo.Point{Lat: 59.9343, Lng: 30.3351})
+
+	placeOrder := func(addressID int32, quantity int32) *httpexpect.Response {
+		return e.POST("/orders").
+			WithHeader("Authorization", customerAuth).
+			WithJSON(map[string]any{
+				"restaurant_id": restaurantID,
+				"address_id":    addressID,
+				"items":         []map[string]any{{"menuitem_id": itemID, "quantity": quantity}},
+			}).Expect()
+	}
+
+	placeOrder(outside, 3).Status(http.StatusConflict)
+	// one burger is below the minimum of both zones
+	placeOrder(inside, 1).Status(http.StatusConflict)
+	// two burgers miss the cheaper zone's minimum but meet the other one
+	order := placeOrder(inside, 2).Status(http.StatusCreated).JSON().Object()
+	order.Value("delivery_fee").Object().Value("amount").Number().IsEqual(19900)
+	order.Value("total_price").Object().Value("amount").Number().IsEqual(69900)
+	order = placeOrder(inside, 3).Status(http.StatusCreated).JSON().Object()
+	order.Value("delivery_fee").Object().Value("amount").Number().IsEqual(9900)
+	order.Value("total_price").Object().Value("amount").Number().IsEqual(84900)
+
+	// a saved address the stub geocoder made up a point for is not trusted until it is pinned
+	unpinned := e.POST("/users/me/addresses").
+		WithHeader("Authorization", customerAuth).
+		WithJSON(newAddress.Request{
+			Street:   "Tverskaya",
+			Building: "9",
+		}).Expect().
+		Status(http.StatusCreated).
+		JSON().Object()
+	unpinned.Value("located_exactly").Boolean().IsFalse()
+	unpinnedID := int32(unpinned.Value("id").Number().Raw())
+	placeOrder(unpinnedID, 3).Status(http.StatusBadRequest)
+	e.PATCH("/users/me/addresses/"+strconv.Itoa(int(unpinnedID))).
+		WithHeader("Authorization", customerAuth).
+		WithJSON(updateAddress.Request{Location: &geo.Point{Lat: 55.7601, Lng: 37.6187}}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("located_exactly").Boolean().IsTrue()
+	placeOrder(unpinnedID, 3).Status(http.StatusCreated)
+
+	freeText := func(location *geo.Point) *httpexpect.Response {
+		return e.POST("/orders").
+			WithHeader("Authorization", customerAuth).
+			WithJSON(map[string]any{
+				"restaurant_id": restaurantID,
+				"address":       "Tverskaya, 9",
+				"location":      location,
+				"items":         []map[string]any{{"menuitem_id": itemID, "quantity": 3}},
+			}).Expect()
+	}
+	// the stub geocoder makes up points, so free text needs a pinned location
+	freeText(nil).Status(http.StatusBadRequest)
+	freeText(&geo.Point{Lat: 55.7601, Lng: 37.6187}).Status(http.StatusCreated)
+
+	e.GET("/restaurants").
+		WithQuery("lat", 55.7558).
+		Expect().
+		Status(http.StatusBadRequest)
+	e.GET("/restaurants").
+		WithQuery("lat", 95).
+		WithQuery("lng", 37.6173).
+		Expect().
+		Status(http.StatusBadRequest)
+	e.GET("/restaurants").
+		WithQuery("lat", 55.7558).
+		WithQuery("lng", 37.6173).
+		Expect().
+		Status(http.StatusOK)
+}